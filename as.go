@@ -0,0 +1,47 @@
+//go:build go1.18
+// +build go1.18
+
+package errkind
+
+// As returns the first error in the chain of causes of err that has type T,
+// which is usually an interface type. It returns false if there is no such
+// error.
+//  type retryAfterer interface {
+//      RetryAfter() time.Duration
+//  }
+//
+//  if ra, ok := errkind.As[retryAfterer](err); ok {
+//      time.Sleep(ra.RetryAfter())
+//  }
+//
+// The chain of causes starts with err and is followed using the Cause method,
+// or the Unwrap method for errors that do not have a Cause method. Errors that
+// wrap more than one error (such as errors created by the standard library
+// errors.Join function) are traversed depth first. This is the same traversal
+// used by StatusCode, Code, IsTemporary, KeyVals, PublicView, Encode, LogValue
+// and the other functions in this package.
+func As[T any](err error) (T, bool) {
+	var target T
+	found := walk(err, func(err error) bool {
+		t, ok := err.(T)
+		if ok {
+			target = t
+		}
+		return ok
+	})
+	return target, found
+}
+
+// All returns every error in the chain of causes of err that has type T,
+// in the order they are traversed by As. It returns nil if there are
+// no such errors.
+func All[T any](err error) []T {
+	var targets []T
+	walk(err, func(err error) bool {
+		if t, ok := err.(T); ok {
+			targets = append(targets, t)
+		}
+		return false
+	})
+	return targets
+}
@@ -0,0 +1,85 @@
+//go:build go1.18
+// +build go1.18
+
+package errkind
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+// testingJoinError wraps multiple errors using the Unwrap convention.
+type testingJoinError []error
+
+func (e testingJoinError) Error() string {
+	return "join"
+}
+
+func (e testingJoinError) Unwrap() []error {
+	return e
+}
+
+func TestAs(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantOK     bool
+	}{
+		{err: nil, wantOK: false},
+		{err: errors.New("no status"), wantOK: false},
+		{err: NotFound(), wantStatus: 404, wantOK: true},
+		{err: errors.Wrap(NotFound(), "wrapped"), wantStatus: 404, wantOK: true},
+		{err: testingUnwrapError{errors.Wrap(BadRequest(), "wrapped")}, wantStatus: 400, wantOK: true},
+		{err: testingJoinError{errors.New("no status"), testingUnwrapError{Forbidden()}, NotFound()}, wantStatus: 403, wantOK: true},
+		{err: errors.Wrap(testingJoinError{errors.New("a"), errors.New("b")}, "wrapped"), wantOK: false},
+	}
+	for i, tt := range tests {
+		sc, ok := As[statusCoder](tt.err)
+		if got, want := ok, tt.wantOK; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+			continue
+		}
+		if ok {
+			if got, want := sc.StatusCode(), tt.wantStatus; got != want {
+				t.Errorf("%d: want=%v, got=%v", i, want, got)
+			}
+		}
+		if got, want := StatusCode(tt.err), tt.wantStatus; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+
+	// concrete types
+	if _, ok := As[testingUnwrapError](errors.Wrap(testingUnwrapError{NotFound()}, "wrapped")); !ok {
+		t.Errorf("want=true, got=false")
+	}
+}
+
+func TestAll(t *testing.T) {
+	err := testingJoinError{
+		errors.Wrap(Temporary("timeout"), "first"),
+		testingUnwrapError{NotFound()},
+		testingJoinError{BadRequest(), errors.New("no status")},
+	}
+	var got []int
+	for _, sc := range All[statusCoder](err) {
+		got = append(got, sc.StatusCode())
+	}
+	if want := []int{404, 400}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := len(All[temporaryer](err)), 1; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got := All[statusCoder](errors.New("no status")); got != nil {
+		t.Errorf("want=nil, got=%v", got)
+	}
+	if got, want := IsTemporary(err), true; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := StatusCode(err), 404; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
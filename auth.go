@@ -0,0 +1,191 @@
+package errkind
+
+import (
+	"bytes"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/jjeffery/errors"
+)
+
+// Challenge is an authentication challenge, which is returned to a
+// requesting client in the WWW-Authenticate header of a response
+// with status 401 (unauthorized). See RFC 7235.
+type Challenge struct {
+	Scheme string            // authentication scheme, eg "Bearer"
+	Realm  string            // protection space, optional
+	Params map[string]string // other parameters, eg "error" and "scope"
+}
+
+// String returns the challenge formatted for the WWW-Authenticate header.
+// The realm is the first parameter, followed by the other parameters in
+// order of their names. Parameter values are quoted.
+//  Bearer realm="example", error="invalid_token"
+func (c Challenge) String() string {
+	var params []string
+	if c.Realm != "" {
+		params = append(params, "realm="+quoteParam(c.Realm))
+	}
+	names := make([]string, 0, len(c.Params))
+	for name := range c.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		params = append(params, name+"="+quoteParam(c.Params[name]))
+	}
+	if len(params) == 0 {
+		return c.Scheme
+	}
+	return c.Scheme + " " + strings.Join(params, ", ")
+}
+
+// quoteParam returns s as an RFC 7230 quoted-string.
+func quoteParam(s string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(s[i])
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// FormatChallenges returns the challenges formatted as the value of
+// a WWW-Authenticate header, or a blank string if there are no challenges.
+func FormatChallenges(challenges []Challenge) string {
+	values := make([]string, len(challenges))
+	for i, c := range challenges {
+		values[i] = c.String()
+	}
+	return strings.Join(values, ", ")
+}
+
+// Error codes for the Bearer authentication scheme. See RFC 6750.
+const (
+	BearerInvalidRequest    = "invalid_request"
+	BearerInvalidToken      = "invalid_token"
+	BearerInsufficientScope = "insufficient_scope"
+)
+
+// challenger is an interface implemented by errors that have
+// authentication challenges.
+type challenger interface {
+	Challenges() []Challenge
+}
+
+// Challenges returns the authentication challenges set
+// when the error was created.
+func (d *details) Challenges() []Challenge {
+	if d == nil {
+		return nil
+	}
+	return d.challenges
+}
+
+// SetChallenges returns an option that sets the authentication
+// challenges of an error.
+func SetChallenges(challenges ...Challenge) Option {
+	return func(d *details) {
+		d.challenges = challenges
+	}
+}
+
+// Challenges returns the authentication challenges of err or one of its
+// causes, or nil if there are none. The challenges closest to err are returned.
+// Because challenges are returned to requesting clients, if err was created by
+// WithPublic the challenges of the masked error are not returned.
+//  if challenges := errkind.Challenges(err); challenges != nil {
+//      w.Header().Set("WWW-Authenticate", errkind.FormatChallenges(challenges))
+//  }
+//
+// An error has challenges if it, or one of its causes, implements
+// the following interface and returns at least one challenge.
+//  type challenger interface {
+//      Challenges() []Challenge
+//  }
+func Challenges(err error) []Challenge {
+	var challenges []Challenge
+	walkPublic(err, func(err error) bool {
+		if c, ok := err.(challenger); ok {
+			challenges = c.Challenges()
+		}
+		return len(challenges) > 0
+	})
+	return challenges
+}
+
+// UnauthorizedWithChallenges returns a client error that has a status of
+// 401 (unauthorized), and the authentication challenges that should be
+// returned to the client (see Challenges).
+//  return errkind.UnauthorizedWithChallenges([]errkind.Challenge{
+//      {Scheme: "Basic", Realm: "example"},
+//  })
+//
+// The returned error has a PublicStatusCode() method, which indicates that the
+// status code is public and can be returned to a client.
+func UnauthorizedWithChallenges(challenges []Challenge, msg ...string) errors.Error {
+	return statusError{
+		message: makeMessage("unauthorized", msg),
+		status:  http.StatusUnauthorized,
+		details: newDetailsWith(1, []Option{SetChallenges(challenges...)}),
+	}
+}
+
+// InvalidToken returns a client error that has a status of 401 (unauthorized),
+// and a Bearer challenge with the "invalid_token" error code, which indicates
+// that the access token is expired, revoked, malformed or invalid. The
+// description is included in the challenge if it is not blank, so it should
+// not contain implementation details. See RFC 6750.
+//  Bearer realm="example", error="invalid_token", error_description="token expired"
+//
+// The returned error has a PublicStatusCode() method, which indicates that the
+// status code is public and can be returned to a client.
+func InvalidToken(realm string, description string) errors.Error {
+	return statusError{
+		message: makeMessage("invalid token", []string{description}),
+		status:  http.StatusUnauthorized,
+		details: newDetailsWith(1, []Option{
+			SetChallenges(bearerChallenge(realm, BearerInvalidToken, description, "")),
+		}),
+	}
+}
+
+// InsufficientScope returns a client error that has a status of 403 (forbidden),
+// and a Bearer challenge with the "insufficient_scope" error code, which indicates
+// that the request requires higher privileges than provided by the access token.
+// The scope is the space-delimited list of scopes required, and is included in
+// the challenge if it is not blank. See RFC 6750.
+//  Bearer realm="example", error="insufficient_scope", scope="widgets:write"
+//
+// The returned error has a PublicStatusCode() method, which indicates that the
+// status code is public and can be returned to a client.
+func InsufficientScope(realm string, scope string) errors.Error {
+	return statusError{
+		message: "insufficient scope",
+		status:  http.StatusForbidden,
+		details: newDetailsWith(1, []Option{
+			SetChallenges(bearerChallenge(realm, BearerInsufficientScope, "", scope)),
+		}),
+	}
+}
+
+// bearerChallenge returns a Bearer challenge, omitting blank parameters.
+func bearerChallenge(realm, errorCode, description, scope string) Challenge {
+	params := map[string]string{"error": errorCode}
+	if description != "" {
+		params["error_description"] = description
+	}
+	if scope != "" {
+		params["scope"] = scope
+	}
+	return Challenge{
+		Scheme: "Bearer",
+		Realm:  realm,
+		Params: params,
+	}
+}
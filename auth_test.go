@@ -0,0 +1,138 @@
+package errkind
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestChallengeString(t *testing.T) {
+	tests := []struct {
+		challenges []Challenge
+		want       string
+	}{
+		{
+			challenges: nil,
+			want:       "",
+		},
+		{
+			challenges: []Challenge{{Scheme: "Negotiate"}},
+			want:       "Negotiate",
+		},
+		{
+			challenges: []Challenge{{Scheme: "Basic", Realm: "example"}},
+			want:       `Basic realm="example"`,
+		},
+		{
+			challenges: []Challenge{{
+				Scheme: "Bearer",
+				Realm:  `say "hi" \o/`,
+				Params: map[string]string{"scope": "a b", "error": "insufficient_scope"},
+			}},
+			want: `Bearer realm="say \"hi\" \\o/", error="insufficient_scope", scope="a b"`,
+		},
+		{
+			challenges: []Challenge{
+				{Scheme: "Newauth", Realm: "apps", Params: map[string]string{"type": "1", "title": "Login to apps"}},
+				{Scheme: "Basic", Realm: "simple"},
+			},
+			want: `Newauth realm="apps", title="Login to apps", type="1", Basic realm="simple"`,
+		},
+	}
+	for i, tt := range tests {
+		if got, want := FormatChallenges(tt.challenges), tt.want; got != want {
+			t.Errorf("%d:\nwant=%v\n got=%v", i, want, got)
+		}
+	}
+}
+
+func TestChallenges(t *testing.T) {
+	basic := []Challenge{{Scheme: "Basic", Realm: "example"}}
+	tests := []struct {
+		err        error
+		errText    string
+		status     int
+		challenges string
+	}{
+		{
+			err:        UnauthorizedWithChallenges(basic),
+			errText:    "unauthorized",
+			status:     401,
+			challenges: `Basic realm="example"`,
+		},
+		{
+			err:        errors.Wrap(UnauthorizedWithChallenges(basic, "no password"), "wrapped"),
+			errText:    "wrapped: no password",
+			status:     401,
+			challenges: `Basic realm="example"`,
+		},
+		{
+			err:        InvalidToken("example", ""),
+			errText:    "invalid token",
+			status:     401,
+			challenges: `Bearer realm="example", error="invalid_token"`,
+		},
+		{
+			err:        InvalidToken("example", "token expired").With("user", "bob"),
+			errText:    "token expired user=bob",
+			status:     401,
+			challenges: `Bearer realm="example", error="invalid_token", error_description="token expired"`,
+		},
+		{
+			err:        InsufficientScope("example", "widgets:write"),
+			errText:    "insufficient scope",
+			status:     403,
+			challenges: `Bearer realm="example", error="insufficient_scope", scope="widgets:write"`,
+		},
+		{
+			err:        Unauthorized(),
+			errText:    "unauthorized",
+			status:     401,
+			challenges: "",
+		},
+		{
+			err:        WithOptions(errors.New("no token"), SetChallenges(Challenge{Scheme: "Bearer"})),
+			errText:    "no token",
+			status:     0,
+			challenges: "Bearer",
+		},
+		{
+			err:        WithPublic(errors.New("token signature invalid"), InvalidToken("example", "token expired")),
+			errText:    "token signature invalid",
+			status:     401,
+			challenges: `Bearer realm="example", error="invalid_token", error_description="token expired"`,
+		},
+		{
+			// challenges of the masked error are not returned
+			err:        WithPublic(UnauthorizedWithChallenges(basic), NotFound()),
+			errText:    "unauthorized",
+			status:     404,
+			challenges: "",
+		},
+		{
+			err:        WithOptions(WithPublic(errors.New("no session"), Unauthorized()), SetChallenges(basic...)),
+			errText:    "no session",
+			status:     401,
+			challenges: `Basic realm="example"`,
+		},
+	}
+	for i, tt := range tests {
+		if got, want := tt.err.Error(), tt.errText; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := StatusCode(tt.err), tt.status; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := FormatChallenges(Challenges(tt.err)), tt.challenges; got != want {
+			t.Errorf("%d:\nwant=%v\n got=%v", i, want, got)
+		}
+	}
+
+	if got := Challenges(nil); got != nil {
+		t.Errorf("want=nil, got=%v", got)
+	}
+	if got, want := Challenges(UnauthorizedWithChallenges(basic)), basic; !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
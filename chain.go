@@ -0,0 +1,79 @@
+package errkind
+
+// unwrapper is an interface implemented by errors that wrap another
+// error using the convention of the standard library errors package.
+type unwrapper interface {
+	Unwrap() error
+}
+
+// multiUnwrapper is an interface implemented by errors that wrap more
+// than one error using the convention of the standard library errors
+// package, such as errors created by errors.Join.
+type multiUnwrapper interface {
+	Unwrap() []error
+}
+
+// walk calls fn for err and each error in its chain of causes, until fn
+// returns true. The chain of causes is followed using the Cause method, or
+// the Unwrap method for errors that do not have a Cause method. Errors that
+// wrap more than one error are traversed depth first, in the order that
+// Unwrap returns them. It returns true if fn returned true.
+func walk(err error, fn func(err error) bool) bool {
+	for err != nil {
+		if fn(err) {
+			return true
+		}
+		switch e := err.(type) {
+		case causer:
+			err = e.Cause()
+		case unwrapper:
+			err = e.Unwrap()
+		case multiUnwrapper:
+			for _, err := range e.Unwrap() {
+				if walk(err, fn) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// walkKind is the same as walk, except that an error that has a public error
+// (see WithPublic) is replaced by its public error, because the public error
+// determines the kind of error. The causes of an error that has a public
+// error are not visited.
+func walkKind(err error, fn func(err error) bool) bool {
+	var found bool
+	walk(err, func(err error) bool {
+		if p, ok := err.(publicErrorer); ok {
+			found = walkKind(p.PublicError(), fn)
+			return true
+		}
+		found = fn(err)
+		return found
+	})
+	return found
+}
+
+// walkPublic is the same as walkKind, except that an error that has a public
+// error is visited before its public error. It is used for details that are
+// returned to requesting clients, which may be set on the error returned by
+// WithPublic (see WithOptions) as well as on its public error.
+func walkPublic(err error, fn func(err error) bool) bool {
+	var found bool
+	walk(err, func(err error) bool {
+		if found = fn(err); found {
+			return true
+		}
+		if p, ok := err.(publicErrorer); ok {
+			found = walkPublic(p.PublicError(), fn)
+			return true
+		}
+		return false
+	})
+	return found
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// entry describes one error in the catalog.
+type entry struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Status      int    `json:"status"`
+	Message     string `json:"message"`
+	Temporary   bool   `json:"temporary"`
+	Description string `json:"description"`
+}
+
+// readCatalog reads the catalog from the named file. Files with a
+// ".json" extension are decoded as JSON, all other files are
+// decoded as YAML.
+func readCatalog(filename string) ([]*entry, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*entry
+	if strings.HasSuffix(strings.ToLower(filename), ".json") {
+		entries, err = decodeJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+	} else {
+		entries, err = decodeYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+	}
+	if err := checkCatalog(entries); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return entries, nil
+}
+
+// decodeJSON decodes a JSON catalog. As for YAML catalogs,
+// unknown fields are rejected, so that typos are reported.
+func decodeJSON(data []byte) ([]*entry, error) {
+	var entries []*entry
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// decodeYAML decodes a catalog in YAML format.
+func decodeYAML(data []byte) ([]*entry, error) {
+	items, err := parseYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*entry, 0, len(items))
+	for _, item := range items {
+		var e entry
+		for _, field := range item.fields {
+			if err := e.set(field.key, field.value); err != nil {
+				return nil, fmt.Errorf("line %d: %v", field.line, err)
+			}
+		}
+		entries = append(entries, &e)
+	}
+	return entries, nil
+}
+
+// set sets the field of the entry identified by key.
+func (e *entry) set(key, value string) error {
+	var err error
+	switch key {
+	case "code":
+		e.Code = value
+	case "name":
+		e.Name = value
+	case "status":
+		e.Status, err = strconv.Atoi(value)
+	case "message":
+		e.Message = value
+	case "temporary":
+		e.Temporary, err = strconv.ParseBool(value)
+	case "description":
+		e.Description = value
+	default:
+		return fmt.Errorf("unknown field %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s: %q", key, value)
+	}
+	return nil
+}
+
+// checkCatalog checks the catalog entries for consistency,
+// and fills in default values.
+func checkCatalog(entries []*entry) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("catalog has no entries")
+	}
+	codes := make(map[string]bool)
+	names := make(map[string]bool)
+	for i, e := range entries {
+		e.Code = strings.TrimSpace(e.Code)
+		if e.Code == "" {
+			return fmt.Errorf("entry %d: missing code", i+1)
+		}
+		if codes[e.Code] {
+			return fmt.Errorf("entry %d: duplicate code %q", i+1, e.Code)
+		}
+		codes[e.Code] = true
+
+		if e.Name == "" {
+			e.Name = nameFromCode(e.Code)
+		}
+		if !token.IsIdentifier(e.Name) || !token.IsExported(e.Name) {
+			return fmt.Errorf("entry %d: cannot use %q as a Go name: specify a name for code %q", i+1, e.Name, e.Code)
+		}
+		if names[e.Name] {
+			return fmt.Errorf("entry %d: duplicate name %q", i+1, e.Name)
+		}
+		names[e.Name] = true
+
+		if e.Status < 400 || e.Status > 599 {
+			return fmt.Errorf("entry %d: code %q: status %d is not a 4xx or 5xx status", i+1, e.Code, e.Status)
+		}
+
+		e.Message = strings.TrimSpace(e.Message)
+		if e.Message == "" {
+			e.Message = strings.ToLower(http.StatusText(e.Status))
+		}
+		if e.Message == "" {
+			return fmt.Errorf("entry %d: code %q: missing message", i+1, e.Code)
+		}
+		e.Description = strings.TrimSpace(e.Description)
+	}
+	return nil
+}
+
+// nameFromCode derives an exported Go name from an error code.
+// Punctuation in the code separates words, so "auth.token-expired"
+// becomes "AuthTokenExpired".
+func nameFromCode(code string) string {
+	words := strings.FieldsFunc(code, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, "")
+}
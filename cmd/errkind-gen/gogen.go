@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+// generateGo generates Go source code for the catalog entries.
+func generateGo(pkg string, source string, entries []*entry) ([]byte, error) {
+	data := struct {
+		Package string
+		Source  string
+		Entries []*entry
+	}{
+		Package: pkg,
+		Source:  source,
+		Entries: entries,
+	}
+
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format generated code: %v", err)
+	}
+	return src, nil
+}
+
+var goTemplate = template.Must(template.New("go").Funcs(template.FuncMap{
+	"comment":    comment,
+	"statusText": statusText,
+}).Parse(`// Code generated by errkind-gen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"github.com/jjeffery/errkind"
+	"github.com/jjeffery/errors"
+)
+
+// Error codes defined in {{.Source}}.
+const (
+{{- range .Entries}}
+	Code{{.Name}} = {{printf "%q" .Code}}
+{{- end}}
+)
+{{range .Entries}}
+// {{.Name}} returns a public error with code {{printf "%q" .Code}}
+// and status {{.Status}} ({{statusText .Status}}).
+{{- if .Temporary}} The error is temporary.{{end}}
+{{- if .Description}}
+//
+{{comment .Description}}
+{{- end}}
+func {{.Name}}() errors.Error {
+{{- if .Temporary}}
+	return errkind.TemporaryPublic({{printf "%q" .Message}}, {{.Status}}, Code{{.Name}})
+{{- else}}
+	return errkind.PublicWithCode({{printf "%q" .Message}}, {{.Status}}, Code{{.Name}})
+{{- end}}
+}
+
+// Is{{.Name}} reports whether err has code {{printf "%q" .Code}}.
+func Is{{.Name}}(err error) bool {
+	return errkind.HasCode(err, Code{{.Name}})
+}
+{{end}}`))
+
+// comment formats text as a Go comment.
+func comment(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("// "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,137 @@
+// Command errkind-gen generates typed error constructors from a catalog
+// of error codes.
+//
+// The catalog is the single source of truth for the errors that an API
+// can return to its clients. Each entry in the catalog describes one error:
+//  code         application-specific error code (required)
+//  name         Go identifier for the error (default derived from code)
+//  status       HTTP status code (required)
+//  message      public error message (default derived from status)
+//  temporary    true if the error condition is temporary
+//  description  description of the error for documentation
+//
+// The catalog file can be JSON (if the file name ends in ".json"),
+// or YAML. Only a subset of YAML is supported: the catalog must be a
+// sequence of mappings with scalar values.
+//  - code: NoSuchKey
+//    status: 404
+//    message: the specified key does not exist
+//    description: The key was deleted or never existed.
+//
+//  - code: ServiceBusy
+//    status: 503
+//    message: service is busy, try again later
+//    temporary: true
+//
+// For each entry errkind-gen generates a constant for the code, a
+// constructor function built on errkind.PublicWithCode, and a predicate
+// function built on errkind.HasCode. It can also generate a Markdown
+// reference table describing each error.
+//
+// Optionally, errkind-gen generates an OpenAPI 3.1 document containing the
+// components/schemas and components/responses for the problem details
+// (RFC 7807) error body, which is the JSON encoding of the errkind.Problem
+// returned by errkind.PublicProblem. There is one response for each status in the
+// catalog, and its schema restricts the code to the codes in the catalog
+// with that status. The document is JSON if the file name ends in
+// ".json", otherwise it is YAML. Other API specifications can reference
+// the responses, for example:
+//  $ref: 'errors.yaml#/components/responses/NotFound'
+//
+// Usage:
+//  errkind-gen [ -pkg package ] [ -o output.go ] [ -doc output.md ] [ -openapi output.yaml ] catalog-file
+//
+// The command is intended to be invoked using go generate:
+//  //go:generate errkind-gen -o errors_gen.go -doc ERRORS.md -openapi errors.yaml errors-catalog.yaml
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	pkgFlag     = flag.String("pkg", "", "package name (default $GOPACKAGE)")
+	outFlag     = flag.String("o", "", "output Go file (default stdout)")
+	docFlag     = flag.String("doc", "", "output Markdown file")
+	openAPIFlag = flag.String("openapi", "", "output OpenAPI file (.json or .yaml)")
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 1 {
+		usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0)); err != nil {
+		fmt.Fprintf(os.Stderr, "errkind-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: errkind-gen [ -pkg package ] [ -o output.go ] [ -doc output.md ] [ -openapi output.yaml ] catalog-file\n")
+	flag.PrintDefaults()
+}
+
+func run(catalogFile string) error {
+	entries, err := readCatalog(catalogFile)
+	if err != nil {
+		return err
+	}
+
+	pkg := *pkgFlag
+	if pkg == "" {
+		pkg = os.Getenv("GOPACKAGE")
+	}
+	if pkg == "" {
+		return fmt.Errorf("cannot determine package name: specify -pkg")
+	}
+
+	src, err := generateGo(pkg, filepath.Base(catalogFile), entries)
+	if err != nil {
+		return err
+	}
+	if err := writeOutput(*outFlag, src); err != nil {
+		return err
+	}
+
+	if *docFlag != "" {
+		doc := generateMarkdown(entries)
+		if err := writeOutput(*docFlag, doc); err != nil {
+			return err
+		}
+	}
+
+	if *openAPIFlag != "" {
+		asJSON := strings.HasSuffix(strings.ToLower(*openAPIFlag), ".json")
+		spec, err := generateOpenAPI(pkg, entries, asJSON)
+		if err != nil {
+			return err
+		}
+		if err := writeOutput(*openAPIFlag, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeOutput writes data to the named file, or to stdout if
+// filename is blank. The file is not written if its contents
+// are unchanged, which keeps file modification times stable.
+func writeOutput(filename string, data []byte) error {
+	if filename == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if existing, err := ioutil.ReadFile(filename); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	return ioutil.WriteFile(filename, data, 0644)
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jjeffery/errkind"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	for _, catalogFile := range []string{"catalog.yaml", "catalog.json"} {
+		entries, err := readCatalog(filepath.Join("testdata", catalogFile))
+		if err != nil {
+			t.Errorf("%s: %v", catalogFile, err)
+			continue
+		}
+
+		src, err := generateGo("apierrors", "catalog.yaml", entries)
+		if err != nil {
+			t.Errorf("%s: %v", catalogFile, err)
+			continue
+		}
+		checkGolden(t, catalogFile, "errors.go.golden", src)
+
+		doc := generateMarkdown(entries)
+		checkGolden(t, catalogFile, "errors.md.golden", doc)
+
+		spec, err := generateOpenAPI("apierrors", entries, false)
+		if err != nil {
+			t.Errorf("%s: %v", catalogFile, err)
+			continue
+		}
+		checkGolden(t, catalogFile, "openapi.yaml.golden", spec)
+
+		spec, err = generateOpenAPI("apierrors", entries, true)
+		if err != nil {
+			t.Errorf("%s: %v", catalogFile, err)
+			continue
+		}
+		checkGolden(t, catalogFile, "openapi.json.golden", spec)
+	}
+}
+
+func checkGolden(t *testing.T, catalogFile string, goldenFile string, got []byte) {
+	t.Helper()
+	goldenFile = filepath.Join("testdata", goldenFile)
+	if *update {
+		if err := ioutil.WriteFile(goldenFile, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s: %s: got:\n%s\nwant:\n%s", catalogFile, goldenFile, got, want)
+	}
+}
+
+func TestOpenAPIDeterministic(t *testing.T) {
+	entries, err := readCatalog(filepath.Join("testdata", "catalog.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// reverse the entries: output should not depend on catalog order
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	for _, asJSON := range []bool{false, true} {
+		goldenFile := filepath.Join("testdata", "openapi.yaml.golden")
+		if asJSON {
+			goldenFile = filepath.Join("testdata", "openapi.json.golden")
+		}
+		want, err := ioutil.ReadFile(goldenFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 3; i++ {
+			got, err := generateOpenAPI("apierrors", entries, asJSON)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s: output differs:\n%s", goldenFile, got)
+			}
+		}
+	}
+}
+
+func TestYAMLString(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"Problem", "Problem"},
+		{"#/components/schemas/Problem", `"#/components/schemas/Problem"`},
+		{"application/problem+json", "application/problem+json"},
+		{"3.1.0", `"3.1.0"`},
+		{"true", `"true"`},
+		{"", `""`},
+		{"a: b", `"a: b"`},
+		{"don't", "don't"},
+	}
+	for i, tt := range tests {
+		if got, want := yamlString(tt.s), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestCatalogErrors(t *testing.T) {
+	tests := []struct {
+		yaml    string
+		wantErr string
+	}{
+		{
+			yaml:    "",
+			wantErr: "catalog has no entries",
+		},
+		{
+			yaml:    "code: NoSuchKey\n",
+			wantErr: "line 1: expected a sequence of mappings",
+		},
+		{
+			yaml:    "- code: NoSuchKey\n  status: not-a-number\n",
+			wantErr: `line 2: invalid status: "not-a-number"`,
+		},
+		{
+			yaml:    "- code: NoSuchKey\n  status: 404\n  colour: blue\n",
+			wantErr: `line 3: unknown field "colour"`,
+		},
+		{
+			yaml:    "- code: NoSuchKey\n  status: 404\n  status: 404\n",
+			wantErr: `line 3: duplicate key "status"`,
+		},
+		{
+			yaml:    "- code: NoSuchKey\n    status: 404\n",
+			wantErr: "line 2: unexpected indentation",
+		},
+		{
+			yaml:    "- code: NoSuchKey\n  description: |\n    multi-line\n",
+			wantErr: "line 2: only single-line scalar values are supported",
+		},
+		{
+			yaml:    "- status: 404\n",
+			wantErr: "entry 1: missing code",
+		},
+		{
+			yaml:    "- code: NoSuchKey\n  status: 404\n- code: NoSuchKey\n  status: 404\n",
+			wantErr: `entry 2: duplicate code "NoSuchKey"`,
+		},
+		{
+			yaml:    "- code: 123\n  status: 404\n",
+			wantErr: `entry 1: cannot use "123" as a Go name: specify a name for code "123"`,
+		},
+		{
+			yaml:    "- code: NoSuchKey\n  status: 200\n",
+			wantErr: `entry 1: code "NoSuchKey": status 200 is not a 4xx or 5xx status`,
+		},
+		{
+			yaml:    "- code: NoSuchKey\n  status: 499\n",
+			wantErr: `entry 1: code "NoSuchKey": missing message`,
+		},
+	}
+	for i, tt := range tests {
+		entries, err := decodeYAML([]byte(tt.yaml))
+		if err == nil {
+			err = checkCatalog(entries)
+		}
+		if err == nil {
+			t.Errorf("%d: want error, got nil", i)
+			continue
+		}
+		if got, want := err.Error(), tt.wantErr; !strings.Contains(got, want) {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestNameFromCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"NoSuchKey", "NoSuchKey"},
+		{"auth.token.expired", "AuthTokenExpired"},
+		{"invalid_token", "InvalidToken"},
+		{"rate-limit exceeded", "RateLimitExceeded"},
+		{"123", "123"},
+	}
+	for i, tt := range tests {
+		if got, want := nameFromCode(tt.code), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestStatusName(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{404, "NotFound"},
+		{503, "ServiceUnavailable"},
+		{499, "Status499"},
+	}
+	for i, tt := range tests {
+		if got, want := statusName(tt.status), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestProblemSchemaMatchesProblem(t *testing.T) {
+	var properties []string
+	for _, kv := range problemSchema() {
+		if kv.key == "properties" {
+			for _, p := range kv.value.(orderedMap) {
+				properties = append(properties, p.key)
+			}
+		}
+	}
+	var fields []string
+	problemType := reflect.TypeOf(errkind.Problem{})
+	for i := 0; i < problemType.NumField(); i++ {
+		tag := problemType.Field(i).Tag.Get("json")
+		fields = append(fields, strings.Split(tag, ",")[0])
+	}
+	if got, want := strings.Join(properties, " "), strings.Join(fields, " "); got != want {
+		t.Errorf("schema properties do not match errkind.Problem: want=%v, got=%v", want, got)
+	}
+}
+
+func TestCatalogJSONUnknownField(t *testing.T) {
+	_, err := decodeJSON([]byte(`[{"code": "ServiceBusy", "status": 503, "temporay": true}]`))
+	if err == nil || !strings.Contains(err.Error(), `unknown field "temporay"`) {
+		t.Errorf("want unknown field error, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// generateMarkdown generates a Markdown reference table
+// for the catalog entries.
+func generateMarkdown(entries []*entry) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("| Code | Status | Message | Temporary | Description |\n")
+	buf.WriteString("|------|--------|---------|-----------|-------------|\n")
+	for _, e := range entries {
+		temporary := "no"
+		if e.Temporary {
+			temporary = "yes"
+		}
+		fmt.Fprintf(&buf, "| `%s` | %d %s | %s | %s | %s |\n",
+			e.Code,
+			e.Status,
+			statusText(e.Status),
+			markdownCell(e.Message),
+			temporary,
+			markdownCell(e.Description),
+		)
+	}
+	return buf.Bytes()
+}
+
+// markdownCell escapes text so that it can be placed in a
+// cell of a Markdown table.
+func markdownCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.Replace(text, "|", `\|`, -1)
+}
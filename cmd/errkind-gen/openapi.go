@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// openAPIVersion is the version of the OpenAPI specification
+// that the generated document conforms to.
+const openAPIVersion = "3.1.0"
+
+// problemMediaType is the media type for problem details (RFC 7807).
+const problemMediaType = "application/problem+json"
+
+// generateOpenAPI generates an OpenAPI document containing schemas and
+// responses for the error statuses and codes in the catalog. The document
+// is JSON if asJSON is true, otherwise YAML. Output is deterministic: the
+// same catalog always generates the same document.
+func generateOpenAPI(pkg string, entries []*entry, asJSON bool) ([]byte, error) {
+	doc := openAPIDocument(pkg, entries)
+	if asJSON {
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+	var buf bytes.Buffer
+	writeYAML(&buf, doc, 0)
+	return buf.Bytes(), nil
+}
+
+// openAPIDocument builds the OpenAPI document. There is one schema and one
+// response for each status in the catalog. Each schema restricts the
+// code to the codes in the catalog with that status.
+func openAPIDocument(pkg string, entries []*entry) orderedMap {
+	codesByStatus := make(map[int][]string)
+	for _, e := range entries {
+		codesByStatus[e.Status] = append(codesByStatus[e.Status], e.Code)
+	}
+	var statuses []int
+	for status := range codesByStatus {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+
+	schemas := orderedMap{
+		{"Problem", problemSchema()},
+	}
+	var responses orderedMap
+	for _, status := range statuses {
+		codes := codesByStatus[status]
+		sort.Strings(codes)
+		name := statusName(status)
+		schemaName := name + "Problem"
+		schemas = append(schemas, keyValue{schemaName, orderedMap{
+			{"allOf", []interface{}{
+				orderedMap{{"$ref", "#/components/schemas/Problem"}},
+				orderedMap{
+					{"type", "object"},
+					{"properties", orderedMap{
+						{"status", orderedMap{{"const", status}}},
+						{"code", orderedMap{{"enum", stringsToValues(codes)}}},
+					}},
+				},
+			}},
+		}})
+		responses = append(responses, keyValue{name, orderedMap{
+			{"description", statusText(status)},
+			{"content", orderedMap{
+				{problemMediaType, orderedMap{
+					{"schema", orderedMap{{"$ref", "#/components/schemas/" + schemaName}}},
+				}},
+			}},
+		}})
+	}
+
+	return orderedMap{
+		{"openapi", openAPIVersion},
+		{"info", orderedMap{
+			{"title", pkg + " error responses"},
+			{"version", "1.0.0"},
+		}},
+		{"components", orderedMap{
+			{"schemas", schemas},
+			{"responses", responses},
+		}},
+	}
+}
+
+// problemSchema returns the schema for the problem details error body,
+// extended with the errkind error code and incident ID. This is the JSON
+// encoding of the errkind.Problem returned by errkind.PublicProblem, and
+// the properties must be kept in sync with the fields of errkind.Problem.
+func problemSchema() orderedMap {
+	return orderedMap{
+		{"type", "object"},
+		{"description", "Problem details (RFC 7807) with an application-specific error code."},
+		{"properties", orderedMap{
+			{"type", orderedMap{{"type", "string"}, {"format", "uri-reference"}}},
+			{"title", orderedMap{{"type", "string"}, {"description", "HTTP status text."}}},
+			{"status", orderedMap{{"type", "integer"}, {"description", "HTTP status code."}}},
+			{"detail", orderedMap{{"type", "string"}, {"description", "Public error message."}}},
+			{"code", orderedMap{{"type", "string"}, {"description", "Application-specific error code."}}},
+			{"incident", orderedMap{{"type", "string"}, {"description", "Incident ID of the logged error."}}},
+		}},
+		{"required", []interface{}{"status", "title"}},
+	}
+}
+
+// statusText returns the text for the status, eg "Not Found" for 404,
+// or "Status 499" for a status that has no standard text.
+func statusText(status int) string {
+	if text := http.StatusText(status); text != "" {
+		return text
+	}
+	return "Status " + strconv.Itoa(status)
+}
+
+// statusName returns a name for the status suitable for use as
+// a schema or response name, eg "NotFound" for 404, or "Status499"
+// for a status that has no standard text.
+func statusName(status int) string {
+	return nameFromCode(statusText(status))
+}
+
+func stringsToValues(a []string) []interface{} {
+	values := make([]interface{}, len(a))
+	for i, s := range a {
+		values[i] = s
+	}
+	return values
+}
+
+// orderedMap is a map whose keys are output in a fixed order.
+type orderedMap []keyValue
+
+type keyValue struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, kv := range m {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(kv.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(kv.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// writeYAML writes value in YAML block style. Supported value
+// types are orderedMap, []interface{}, string, int and bool.
+func writeYAML(buf *bytes.Buffer, value interface{}, indent int) {
+	prefix := strings.Repeat("  ", indent)
+	switch v := value.(type) {
+	case orderedMap:
+		for _, kv := range v {
+			buf.WriteString(prefix + yamlString(kv.key) + ":")
+			writeYAMLValue(buf, kv.value, indent)
+		}
+	case []interface{}:
+		for _, item := range v {
+			buf.WriteString(prefix + "-")
+			if m, ok := item.(orderedMap); ok && len(m) > 0 {
+				// first key on the same line as the dash
+				var first bytes.Buffer
+				writeYAML(&first, m, indent+1)
+				buf.WriteString(" " + strings.TrimPrefix(first.String(), prefix+"  "))
+				continue
+			}
+			writeYAMLValue(buf, item, indent)
+		}
+	}
+}
+
+// writeYAMLValue writes a value following a mapping key or sequence dash.
+func writeYAMLValue(buf *bytes.Buffer, value interface{}, indent int) {
+	switch v := value.(type) {
+	case orderedMap:
+		if len(v) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+		buf.WriteString("\n")
+		writeYAML(buf, v, indent+1)
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+		buf.WriteString("\n")
+		writeYAML(buf, v, indent+1)
+	default:
+		buf.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+func yamlScalar(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return yamlString(s)
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// yamlString returns s as a YAML string, quoted if necessary.
+func yamlString(s string) string {
+	if needsYAMLQuote(s) {
+		data, _ := json.Marshal(s)
+		return string(data)
+	}
+	return s
+}
+
+func needsYAMLQuote(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`0123456789.+") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+	for _, r := range s {
+		if r < ' ' || r > '~' {
+			return true
+		}
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~", "y", "n":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlItem is one mapping in a YAML sequence.
+type yamlItem struct {
+	fields []yamlField
+}
+
+// yamlField is a key/value pair in a YAML mapping.
+type yamlField struct {
+	key   string
+	value string
+	line  int
+}
+
+// parseYAML parses the subset of YAML used for catalog files: a
+// sequence of mappings, where each mapping contains scalar values.
+// Scalar values can be plain, single-quoted or double-quoted, but
+// must fit on a single line.
+func parseYAML(data []byte) ([]*yamlItem, error) {
+	var (
+		items  []*yamlItem
+		item   *yamlItem
+		indent int
+	)
+	for i, line := range strings.Split(string(data), "\n") {
+		lineNum := i + 1
+		line = strings.TrimRight(stripComment(line), " \t\r")
+		if strings.TrimSpace(line) == "" || line == "---" {
+			continue
+		}
+		if strings.HasPrefix(line, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not permitted for indentation", lineNum)
+		}
+		text := strings.TrimLeft(line, " ")
+		lineIndent := len(line) - len(text)
+
+		if text == "-" || strings.HasPrefix(text, "- ") {
+			if lineIndent != 0 {
+				return nil, fmt.Errorf("line %d: nested sequences are not supported", lineNum)
+			}
+			item = &yamlItem{}
+			items = append(items, item)
+			text = strings.TrimLeft(strings.TrimPrefix(text, "-"), " ")
+			if text == "" {
+				indent = 0
+				continue
+			}
+			indent = len(line) - len(text)
+		} else {
+			if item == nil {
+				return nil, fmt.Errorf("line %d: expected a sequence of mappings", lineNum)
+			}
+			if indent == 0 {
+				indent = lineIndent
+			}
+			if lineIndent == 0 || lineIndent != indent {
+				return nil, fmt.Errorf("line %d: unexpected indentation", lineNum)
+			}
+		}
+
+		field, err := parseYAMLField(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+		for _, f := range item.fields {
+			if f.key == field.key {
+				return nil, fmt.Errorf("line %d: duplicate key %q", lineNum, field.key)
+			}
+		}
+		field.line = lineNum
+		item.fields = append(item.fields, field)
+	}
+	return items, nil
+}
+
+// parseYAMLField parses a "key: value" pair.
+func parseYAMLField(text string) (yamlField, error) {
+	var field yamlField
+	colon := strings.Index(text, ":")
+	if colon <= 0 || (colon+1 < len(text) && text[colon+1] != ' ') {
+		return field, fmt.Errorf("expected \"key: value\"")
+	}
+	field.key = strings.TrimSpace(text[:colon])
+	value := strings.TrimSpace(text[colon+1:])
+	switch {
+	case strings.HasPrefix(value, `"`):
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return field, fmt.Errorf("invalid double-quoted string: %s", value)
+		}
+		value = unquoted
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return field, fmt.Errorf("invalid single-quoted string: %s", value)
+		}
+		value = strings.Replace(value[1:len(value)-1], "''", "'", -1)
+	case strings.HasPrefix(value, "|"), strings.HasPrefix(value, ">"),
+		strings.HasPrefix(value, "["), strings.HasPrefix(value, "{"):
+		return field, fmt.Errorf("only single-line scalar values are supported")
+	}
+	field.value = value
+	return field, nil
+}
+
+// stripComment removes any comment from the line. A comment starts
+// with a '#' at the start of the line or following whitespace, and
+// not inside a quoted string. A quoted string starts with a quote
+// character at the start of the line or following a space.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (i == 0 || line[i-1] == ' '):
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,90 @@
+package errkind
+
+import "strings"
+
+// codeSeparator separates the segments of hierarchical codes.
+const codeSeparator = "."
+
+// CodePath returns the segments of the hierarchical code associated with err,
+// or nil if there is no code. Hierarchical codes have segments separated by
+// dots, from the most general to the most specific.
+//  errkind.CodePath(err) // returns []string{"auth", "token", "expired"}
+//                        // for code "auth.token.expired"
+func CodePath(err error) []string {
+	code := Code(err)
+	if code == "" {
+		return nil
+	}
+	return strings.Split(code, codeSeparator)
+}
+
+// HasCodePrefix determines whether the error has a hierarchical code that
+// is in the family of codes identified by any of the prefixes. A code is in
+// the family identified by a prefix if it is equal to the prefix, or if it
+// starts with the prefix followed by a dot. Prefixes match whole segments,
+// so the prefix "auth.token" matches the codes "auth.token" and
+// "auth.token.expired", but not "auth.tokens". An empty prefix does not
+// match any code.
+//  if errkind.HasCodePrefix(err, "auth.token") {
+//      // token is expired, revoked, etc
+//  }
+func HasCodePrefix(err error, prefixes ...string) bool {
+	return hasCodePrefix(err, prefixes, strings.HasPrefix)
+}
+
+// HasCodePrefixFold is like HasCodePrefix, but the comparison of codes
+// and prefixes is case-insensitive.
+func HasCodePrefixFold(err error, prefixes ...string) bool {
+	return hasCodePrefix(err, prefixes, hasPrefixFold)
+}
+
+// HasCodeFold is like HasCode, but the comparison of codes
+// is case-insensitive.
+func HasCodeFold(err error, codes ...string) bool {
+	return matchCode(err, func(errCode string) bool {
+		for _, code := range codes {
+			if strings.EqualFold(errCode, code) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func hasCodePrefix(err error, prefixes []string, hasPrefix func(s, prefix string) bool) bool {
+	return matchCode(err, func(code string) bool {
+		for _, prefix := range prefixes {
+			if prefix == "" || !hasPrefix(code, prefix) {
+				continue
+			}
+			if len(code) == len(prefix) || strings.HasPrefix(code[len(prefix):], codeSeparator) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// matchCode determines whether the code of err is not blank and match returns
+// true for it. Errors that have more than one code, such as errors created by
+// Join, match if any of their codes match.
+func matchCode(err error, match func(code string) bool) bool {
+	var matched bool
+	walkKind(err, func(err error) bool {
+		if cm, ok := err.(codeMatcher); ok {
+			matched = cm.matchCode(match)
+			return true
+		}
+		code, ok := codeOf(err)
+		if ok {
+			matched = code != "" && match(code)
+		}
+		return ok
+	})
+	return matched
+}
+
+// hasPrefixFold is a case-insensitive version of strings.HasPrefix.
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
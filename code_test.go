@@ -0,0 +1,85 @@
+package errkind
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestCodePath(t *testing.T) {
+	tests := []struct {
+		err  error
+		want []string
+	}{
+		{err: nil, want: nil},
+		{err: NotFound(), want: nil},
+		{err: PublicWithCode("expired", 401, "auth"), want: []string{"auth"}},
+		{err: PublicWithCode("expired", 401, "auth.token.expired"), want: []string{"auth", "token", "expired"}},
+		{err: errors.Wrap(testingCodeError("a.b"), "wrapped"), want: []string{"a", "b"}},
+	}
+	for i, tt := range tests {
+		if got, want := CodePath(tt.err), tt.want; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestHasCodePrefix(t *testing.T) {
+	tests := []struct {
+		code     string
+		prefixes []string
+		want     bool
+		wantFold bool
+	}{
+		{code: "auth.token.expired", prefixes: []string{"auth.token"}, want: true, wantFold: true},
+		{code: "auth.token.expired", prefixes: []string{"auth"}, want: true, wantFold: true},
+		{code: "auth.token.expired", prefixes: []string{"auth.token.expired"}, want: true, wantFold: true},
+		{code: "auth.token", prefixes: []string{"auth.token"}, want: true, wantFold: true},
+		{code: "auth.tokens", prefixes: []string{"auth.token"}, want: false, wantFold: false},
+		{code: "auth.token", prefixes: []string{"auth.token.expired"}, want: false, wantFold: false},
+		{code: "auth.token.expired", prefixes: []string{"billing", "auth.token"}, want: true, wantFold: true},
+		{code: "auth.token.expired", prefixes: []string{""}, want: false, wantFold: false},
+		{code: "auth.token.expired", prefixes: nil, want: false, wantFold: false},
+		{code: "Auth.Token.Expired", prefixes: []string{"auth.token"}, want: false, wantFold: true},
+		{code: "", prefixes: []string{"auth"}, want: false, wantFold: false},
+	}
+	for i, tt := range tests {
+		err := errors.Wrap(testingCodeError(tt.code), "wrapped")
+		if got, want := HasCodePrefix(err, tt.prefixes...), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := HasCodePrefixFold(err, tt.prefixes...), tt.wantFold; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestHasCodeFold(t *testing.T) {
+	tests := []struct {
+		err   error
+		codes []string
+		want  bool
+	}{
+		{err: nil, codes: []string{""}, want: false},
+		{err: testingCodeError("NoSuchKey"), codes: []string{"nosuchkey"}, want: true},
+		{err: testingCodeError("NoSuchKey"), codes: []string{"NoSuchBucket", "NOSUCHKEY"}, want: true},
+		{err: testingCodeError("NoSuchKey"), codes: []string{"NoSuchBucket"}, want: false},
+		{err: testingCodeError(""), codes: []string{""}, want: false},
+	}
+	for i, tt := range tests {
+		if got, want := HasCodeFold(tt.err, tt.codes...), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+type testingCodeError string
+
+func (err testingCodeError) Error() string {
+	return "testing code error"
+}
+
+func (err testingCodeError) Code() string {
+	return string(err)
+}
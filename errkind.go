@@ -25,6 +25,11 @@
 //      StatusCode() int
 //  }
 //
+// Other packages use different method names, such as HTTPStatusCode() or
+// ErrorCode(), or return named types. The method names recognised in addition
+// to the coder and statusCoder interfaces are configured using CodeMethods and
+// StatusCodeMethods.
+//
 // The publicMessager interface identifies an error as having a message suitable
 // for displaying to a requesting client. The error message does not contain any
 // implementation details that could leak sensitive information.
@@ -38,6 +43,15 @@
 //      PublicStatusCode()
 //  }
 //
+// The publicCoder interface identifies an error as having a code suitable
+// for returning to a requesting client.
+//  type publicCoder interface {
+//      PublicCode()
+//  }
+//
+// The PublicView function uses these interfaces to build a view of any error
+// that is safe to return to a requesting client.
+//
 package errkind
 
 import (
@@ -54,6 +68,50 @@ type causer interface {
 	Cause() error
 }
 
+// publicErrorer is an interface implemented by errors that present a
+// different, public error to requesting clients. See WithPublic.
+type publicErrorer interface {
+	PublicError() error
+}
+
+// cause returns the error that determines the kind of err, which is the
+// last error in its chain of causes. The chain of causes is followed in the
+// same way as walk, using the Cause or Unwrap method, except that an error
+// that wraps more than one error (such as an error created by Join) is its
+// own cause. If an error in the chain of causes has a public error, the
+// cause is the cause of the public error.
+func cause(err error) error {
+	var c error
+	walk(err, func(err error) bool {
+		if p, ok := err.(publicErrorer); ok {
+			c = cause(p.PublicError())
+			return true
+		}
+		c = err
+		return isMultiWrapper(err)
+	})
+	return c
+}
+
+// isMultiWrapper reports whether walk follows err to more than one error.
+func isMultiWrapper(err error) bool {
+	switch err.(type) {
+	case causer, unwrapper:
+		return false
+	case multiUnwrapper:
+		return true
+	}
+	return false
+}
+
+// face returns the public error of err, if it has one, otherwise err.
+func face(err error) error {
+	if p, ok := err.(publicErrorer); ok {
+		return p.PublicError()
+	}
+	return err
+}
+
 // temporaryer is an interface implemented by errors that communicate
 // if they are temporary or not. Temporary errors can be retried.
 type temporaryer interface {
@@ -92,19 +150,23 @@ type publicCoder interface {
 
 // HasCode determines whether the error has any of the codes associated with it.
 func HasCode(err error, codes ...string) bool {
-	err = errors.Cause(err)
-	if err == nil {
-		return false
-	}
-	if errCoder, ok := err.(coder); ok {
-		errCode := errCoder.Code()
-		for _, code := range codes {
-			if errCode == code {
-				return true
+	var has bool
+	walkKind(err, func(err error) bool {
+		if ch, ok := err.(codeHaser); ok {
+			has = ch.HasCode(codes...)
+			return true
+		}
+		errCode, ok := codeOf(err)
+		if ok {
+			for _, code := range codes {
+				if errCode == code {
+					has = true
+				}
 			}
 		}
-	}
-	return false
+		return ok
+	})
+	return has
 }
 
 // HasStatusCode determines whether the error has any of the statuses associated with it.
@@ -119,16 +181,16 @@ func HasStatusCode(err error, statusCodes ...int) bool {
 }
 
 // StatusCode returns the status code associated with err, or
-// zero if there is no status.
+// zero if there is no status. The status code is reported by the
+// first error in the chain of causes that has a status code.
 func StatusCode(err error) int {
-	err = errors.Cause(err)
-	if err == nil {
-		return 0
-	}
-	if errStatusCoder, ok := err.(statusCoder); ok {
-		return errStatusCoder.StatusCode()
-	}
-	return 0
+	var statusCode int
+	walkKind(err, func(err error) bool {
+		var ok bool
+		statusCode, ok = statusCodeOf(err)
+		return ok
+	})
+	return statusCode
 }
 
 // Status does the same thing as StatusCode.
@@ -139,41 +201,44 @@ func Status(err error) int {
 }
 
 // Code returns the string error code associated with err, or
-// a blank string if there is no code.
+// a blank string if there is no code. The code is reported by the
+// first error in the chain of causes that has a code.
 func Code(err error) string {
-	err = errors.Cause(err)
-	if err == nil {
-		return ""
-	}
-	if errCoder, ok := err.(coder); ok {
-		return errCoder.Code()
-	}
-	return ""
+	var code string
+	walkKind(err, func(err error) bool {
+		var ok bool
+		code, ok = codeOf(err)
+		return ok
+	})
+	return code
 }
 
 // IsTemporary returns true for errors that indicate
 // an error condition that may succeed if retried.
 //
-// An error is considered temporary if it implements
-// the following interface and its Temporary method returns true.
+// An error is considered temporary if the first error in its chain
+// of causes that implements the following interface has a Temporary
+// method that returns true.
 //  type temporaryer interface {
 //      Temporary() bool
 //  }
 func IsTemporary(err error) bool {
-	err = errors.Cause(err)
-	for err == nil {
-		return false
-	}
-	if temporary, ok := err.(temporaryer); ok {
-		return temporary.Temporary()
-	}
-	return false
+	var temporary bool
+	walk(err, func(err error) bool {
+		t, ok := err.(temporaryer)
+		if ok {
+			temporary = t.Temporary()
+		}
+		return ok
+	})
+	return temporary
 }
 
 // statusError implements error, statusCoder and publicer interfaces.
 type statusError struct {
 	message string
 	status  int
+	*details
 }
 
 func (s statusError) Error() string {
@@ -186,8 +251,22 @@ func (s statusError) StatusCode() int {
 
 func (s statusError) PublicStatusCode() {}
 
+func (s statusError) Format(f fmt.State, c rune) {
+	format(f, c, s)
+}
+
+func (s statusError) MarshalJSON() ([]byte, error) {
+	return Encode(s)
+}
+
 func (s statusError) With(keyvals ...interface{}) errors.Error {
-	return errors.Wrap(s).With(keyvals...)
+	return with(s, keyvals)
+}
+
+func (s statusError) withDetails(fn func(*details)) errors.Error {
+	s.details = s.details.clone()
+	fn(s.details)
+	return s
 }
 
 // publicStatusError implements error, statusCoder and publicMessager interfaces.
@@ -197,11 +276,30 @@ type publicStatusError struct {
 
 func (s publicStatusError) PublicMessage() {}
 
+func (s publicStatusError) Format(f fmt.State, c rune) {
+	format(f, c, s)
+}
+
+func (s publicStatusError) MarshalJSON() ([]byte, error) {
+	return Encode(s)
+}
+
+func (s publicStatusError) With(keyvals ...interface{}) errors.Error {
+	return with(s, keyvals)
+}
+
+func (s publicStatusError) withDetails(fn func(*details)) errors.Error {
+	s.details = s.details.clone()
+	fn(s.details)
+	return s
+}
+
 // publicStatusCodeError implements error, statusCoder, coder and publicMessager interfaces.
 type publicStatusCodeError struct {
 	message string
 	status  int
 	code    string
+	*details
 }
 
 func (s publicStatusCodeError) Error() string {
@@ -229,8 +327,22 @@ func (s publicStatusCodeError) PublicStatusCode() {}
 
 func (s publicStatusCodeError) PublicCode() {}
 
+func (s publicStatusCodeError) Format(f fmt.State, c rune) {
+	format(f, c, s)
+}
+
+func (s publicStatusCodeError) MarshalJSON() ([]byte, error) {
+	return Encode(s)
+}
+
 func (s publicStatusCodeError) With(keyvals ...interface{}) errors.Error {
-	return errors.Wrap(s).With(keyvals...)
+	return with(s, keyvals)
+}
+
+func (s publicStatusCodeError) withDetails(fn func(*details)) errors.Error {
+	s.details = s.details.clone()
+	fn(s.details)
+	return s
 }
 
 // makeMessage returns a string message based on a default message,
@@ -268,6 +380,7 @@ func Public(message string, status int) errors.Error {
 		statusError{
 			message: message,
 			status:  status,
+			details: newDetails(1),
 		},
 	}
 }
@@ -287,12 +400,19 @@ func PublicWithCode(message string, status int, code string) errors.Error {
 	code = strings.TrimSpace(code)
 	if code == "" {
 		// no code supplied
-		return Public(message, status)
+		return publicStatusError{
+			statusError{
+				message: message,
+				status:  status,
+				details: newDetails(1),
+			},
+		}
 	}
 	return publicStatusCodeError{
 		message: message,
 		status:  status,
 		code:    code,
+		details: newDetails(1),
 	}
 }
 
@@ -316,29 +436,43 @@ func PublicWithCode(message string, status int, code string) errors.Error {
 //      // ... can provide err.Error() to the client
 //  }
 func HasPublicMessage(err error) bool {
-	_, ok := err.(publicMessager)
+	_, ok := face(err).(publicMessager)
 	return ok
 }
 
-/*********************
-
-TODO(jpj): maybe include in the public api
-
 // HasPublicStatusCode returns true for errors that indicate
 // that their status code does not contain sensitive information
-// and can be displayed to external clients.
+// and can be returned to external clients.
 //
 // An error has a public status code if it implements
 // the following interface.
 //  type publicStatusCoder interface {
 //      PublicStatusCode()
 //  }
+//
+// As with HasPublicMessage, it usually makes sense to obtain the
+// cause of an error first before testing to see if it is public.
 func HasPublicStatusCode(err error) bool {
-	_, ok := err.(publicStatusCoder)
+	_, ok := face(err).(publicStatusCoder)
 	return ok
 }
 
-***************************/
+// HasPublicCode returns true for errors that indicate
+// that their code does not contain sensitive information
+// and can be returned to external clients.
+//
+// An error has a public code if it implements
+// the following interface.
+//  type publicCoder interface {
+//      PublicCode()
+//  }
+//
+// As with HasPublicMessage, it usually makes sense to obtain the
+// cause of an error first before testing to see if it is public.
+func HasPublicCode(err error) bool {
+	_, ok := face(err).(publicCoder)
+	return ok
+}
 
 // BadRequest returns an client error that has a status of 400 (bad request).
 //
@@ -348,6 +482,7 @@ func BadRequest(msg ...string) errors.Error {
 	return statusError{
 		message: makeMessage("bad request", msg),
 		status:  http.StatusBadRequest,
+		details: newDetails(1),
 	}
 }
 
@@ -359,6 +494,7 @@ func Unauthorized(msg ...string) errors.Error {
 	return statusError{
 		message: makeMessage("unauthorized", msg),
 		status:  http.StatusUnauthorized,
+		details: newDetails(1),
 	}
 }
 
@@ -370,6 +506,7 @@ func Forbidden(msg ...string) errors.Error {
 	return statusError{
 		message: makeMessage("forbidden", msg),
 		status:  http.StatusForbidden,
+		details: newDetails(1),
 	}
 }
 
@@ -381,6 +518,7 @@ func NotFound(msg ...string) errors.Error {
 	return statusError{
 		message: makeMessage("not found", msg),
 		status:  http.StatusNotFound,
+		details: newDetails(1),
 	}
 }
 
@@ -392,20 +530,48 @@ func NotImplemented(msg ...string) errors.Error {
 	return statusError{
 		message: makeMessage("not implemented", msg),
 		status:  http.StatusNotImplemented,
+		details: newDetails(1),
 	}.With("caller", stack.Caller(1))
 }
 
-type temporaryError string
+// temporaryError implements error and temporaryer interfaces.
+type temporaryError struct {
+	message string
+	*details
+}
 
 func (t temporaryError) Error() string {
-	return string(t)
+	return t.message
 }
 
 func (t temporaryError) Temporary() bool {
 	return true
 }
 
+func (t temporaryError) Format(f fmt.State, c rune) {
+	format(f, c, t)
+}
+
+func (t temporaryError) MarshalJSON() ([]byte, error) {
+	return Encode(t)
+}
+
+func (t temporaryError) With(keyvals ...interface{}) errors.Error {
+	return with(t, keyvals)
+}
+
+func (t temporaryError) withDetails(fn func(*details)) errors.Error {
+	t.details = t.details.clone()
+	fn(t.details)
+	return t
+}
+
 // Temporary returns an error that indicates it is temporary.
+// Use ServiceUnavailable, TemporaryWithStatus or TemporaryPublic
+// for a temporary error that has a status code.
 func Temporary(msg string) errors.Error {
-	return errors.Wrap(temporaryError(msg))
+	return temporaryError{
+		message: msg,
+		details: newDetails(1),
+	}
 }
@@ -55,3 +55,32 @@ func ExampleNotImplemented() {
 	// message for not implemented caller="example_test.go:44" (501)
 	// not implemented caller="example_test.go:50" (501)
 }
+
+func ExamplePublicView() {
+	// the cause of the error is public, but the key/value pairs are not
+	var err error = PublicWithCode("widget is locked", 409, "WidgetLocked").With("id", 42)
+	fmt.Printf("%+v\n", PublicView(err))
+
+	// the status is public, but the message is not
+	err = NotFound("widgets table does not exist")
+	fmt.Printf("%+v\n", PublicView(err))
+
+	// nothing is public
+	err = fmt.Errorf("cannot connect to %s", "10.0.0.1")
+	fmt.Printf("%+v\n", PublicView(err))
+
+	// Output:
+	// {Status:409 Code:WidgetLocked Message:widget is locked Incident:}
+	// {Status:404 Code: Message:not found Incident:}
+	// {Status:500 Code: Message:internal server error Incident:}
+}
+
+func ExampleKeyVals() {
+	err := NotFound().With("table", "widgets", "id", 42)
+	fmt.Println(KeyVals(err))
+	fmt.Println(KeyValMap(err, FirstValue))
+
+	// Output:
+	// [table widgets id 42]
+	// map[id:42 table:widgets]
+}
@@ -0,0 +1,103 @@
+package errkind
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Exit codes for command-line programs, as defined in the BSD sysexits.h header.
+const (
+	ExitOK          = 0  // successful termination
+	ExitFailure     = 1  // general failure
+	ExitUsage       = 64 // command line usage error (EX_USAGE)
+	ExitDataErr     = 65 // data format error (EX_DATAERR)
+	ExitNoInput     = 66 // cannot open input (EX_NOINPUT)
+	ExitUnavailable = 69 // service unavailable (EX_UNAVAILABLE)
+	ExitSoftware    = 70 // internal software error (EX_SOFTWARE)
+	ExitTempFail    = 75 // temporary failure, user is invited to retry (EX_TEMPFAIL)
+	ExitNoPerm      = 77 // permission denied (EX_NOPERM)
+)
+
+// ExitCodes contains exit codes for errors with particular codes, which take
+// precedence over the exit codes that ExitCode derives from the status code.
+//  errkind.ExitCodes["NoSuchKey"] = errkind.ExitNoInput
+//
+// ExitCodes should only be modified during program initialization.
+var ExitCodes = map[string]int{}
+
+// exitStatuses maps status codes to exit codes.
+var exitStatuses = map[int]int{
+	http.StatusBadRequest:          ExitUsage,
+	http.StatusUnauthorized:        ExitNoPerm,
+	http.StatusForbidden:           ExitNoPerm,
+	http.StatusNotFound:            ExitNoInput,
+	http.StatusUnprocessableEntity: ExitDataErr,
+	http.StatusServiceUnavailable:  ExitUnavailable,
+}
+
+// ExitCode returns the exit code that a command-line program should exit with
+// when it fails with err. If the code of err (see Code) is in ExitCodes, that
+// exit code is returned. Otherwise the exit code is derived from the error:
+//  ExitUsage        if the status code is 400 (bad request)
+//  ExitNoPerm       if the status code is 401 (unauthorized) or 403 (forbidden)
+//  ExitNoInput      if the status code is 404 (not found)
+//  ExitDataErr      if the status code is 422 (unprocessable entity)
+//  ExitUnavailable  if the status code is 503 (service unavailable)
+//  ExitTempFail     if the error is temporary (see IsTemporary)
+//  ExitFailure      for any other error
+//
+// The status code takes precedence over the error being temporary, so the
+// temporary error returned by ServiceUnavailable gives ExitUnavailable.
+//
+// If err is nil, ExitCode returns ExitOK.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if code := Code(err); code != "" {
+		if exitCode, ok := ExitCodes[code]; ok {
+			return exitCode
+		}
+	}
+	if exitCode, ok := exitStatuses[StatusCode(err)]; ok {
+		return exitCode
+	}
+	if IsTemporary(err) {
+		return ExitTempFail
+	}
+	return ExitFailure
+}
+
+// Variables replaced during testing.
+var (
+	exit             = os.Exit
+	stderr io.Writer = os.Stderr
+)
+
+// Exit prints a message describing err to standard error, and exits the
+// program with the exit code returned by ExitCode. The message is prefixed
+// with the program name, and is only the message of err if it is public (see
+// PublicView), because command-line programs are often run by users that are
+// not interested in implementation details. Otherwise a generic message is
+// printed. The incident ID is included in the message if err has one.
+//  func main() {
+//      errkind.Exit(run())
+//  }
+//
+// If err is nil, Exit exits the program with ExitOK without printing anything.
+func Exit(err error) {
+	if err == nil {
+		exit(ExitOK)
+		return
+	}
+	view := PublicViewWithFallback(err, View{Message: "unexpected error"})
+	msg := view.Message
+	if view.Incident != "" {
+		msg = fmt.Sprintf("%s (incident %s)", msg, view.Incident)
+	}
+	fmt.Fprintf(stderr, "%s: %s\n", filepath.Base(os.Args[0]), msg)
+	exit(ExitCode(err))
+}
@@ -0,0 +1,95 @@
+package errkind
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestExitCode(t *testing.T) {
+	ExitCodes["NoSuchKey"] = ExitNoInput
+	defer delete(ExitCodes, "NoSuchKey")
+
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: nil, want: ExitOK},
+		{err: errors.New("cannot connect"), want: ExitFailure},
+		{err: BadRequest(), want: ExitUsage},
+		{err: Public("invalid widget", 422), want: ExitDataErr},
+		{err: Unauthorized(), want: ExitNoPerm},
+		{err: Forbidden(), want: ExitNoPerm},
+		{err: NotFound(), want: ExitNoInput},
+		{err: errors.Wrap(NotFound(), "cannot get widget"), want: ExitNoInput},
+		{err: Temporary("timeout"), want: ExitTempFail},
+		{err: Public("down for maintenance", 503), want: ExitUnavailable},
+		{err: ServiceUnavailable(), want: ExitUnavailable},
+		{err: NotImplemented(), want: ExitFailure},
+		{err: PublicWithCode("no such key", 400, "NoSuchKey"), want: ExitNoInput},
+		{err: PublicWithCode("locked", 409, "Locked"), want: ExitFailure},
+	}
+	for i, tt := range tests {
+		if got, want := ExitCode(tt.err), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestExit(t *testing.T) {
+	defer func(e func(int), w io.Writer) {
+		exit, stderr = e, w
+	}(exit, stderr)
+
+	prog := filepath.Base(os.Args[0])
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	tests := []struct {
+		err      error
+		wantCode int
+		wantText string
+	}{
+		{
+			err:      nil,
+			wantCode: ExitOK,
+			wantText: "",
+		},
+		{
+			err:      Public("no such widget", 404),
+			wantCode: ExitNoInput,
+			wantText: prog + ": no such widget\n",
+		},
+		{
+			err:      Forbidden(),
+			wantCode: ExitNoPerm,
+			wantText: prog + ": forbidden\n",
+		},
+		{
+			err:      errors.New("cannot connect to 10.0.0.1"),
+			wantCode: ExitFailure,
+			wantText: prog + ": unexpected error\n",
+		},
+		{
+			err:      WithIncident(ctx, Temporary("timeout")),
+			wantCode: ExitTempFail,
+			wantText: prog + ": unexpected error (incident req-1)\n",
+		},
+	}
+	for i, tt := range tests {
+		var buf bytes.Buffer
+		code := -1
+		exit = func(c int) { code = c }
+		stderr = &buf
+		Exit(tt.err)
+		if got, want := code, tt.wantCode; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := buf.String(), tt.wantText; got != want {
+			t.Errorf("%d: want=%q, got=%q", i, want, got)
+		}
+	}
+}
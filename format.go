@@ -0,0 +1,97 @@
+package errkind
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-stack/stack"
+)
+
+// format implements the fmt.Formatter interface for errors in this package.
+// All verbs except %+v format the error message, which is the result of the
+// Error method, in the same way as the fmt package formats a string, including
+// any flags, width and precision. The %+v verb prints a verbose description of
+// the error, starting with the error message, followed by one line for each of:
+//  status     the status code, if any (see StatusCode)
+//  code       the code, if any (see Code)
+//  temporary  if the error is temporary (see IsTemporary)
+//  public     which of the message, status and code are public (see PublicView)
+//  key/value  each key/value pair attached to the error and its causes (see KeyVals)
+//  stack      the stack trace, if captured (see StackTrace)
+func format(f fmt.State, c rune, err error) {
+	if c == 'v' && f.Flag('+') {
+		writeVerbose(f, err)
+		return
+	}
+	fmt.Fprintf(f, formatSpec(f, c), err.Error())
+}
+
+// formatSpec returns the format specifier for the verb, including
+// the flags, width and precision of f.
+func formatSpec(f fmt.State, c rune) string {
+	spec := []byte{'%'}
+	for _, flag := range []byte("+-# 0") {
+		if f.Flag(int(flag)) {
+			spec = append(spec, flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		spec = strconv.AppendInt(spec, int64(width), 10)
+	}
+	if precision, ok := f.Precision(); ok {
+		spec = append(spec, '.')
+		spec = strconv.AppendInt(spec, int64(precision), 10)
+	}
+	return string(append(spec, string(c)...))
+}
+
+// writeVerbose writes the verbose description of err.
+func writeVerbose(w io.Writer, err error) {
+	io.WriteString(w, err.Error())
+	if status := StatusCode(err); status != 0 {
+		fmt.Fprintf(w, "\nstatus: %d", status)
+	}
+	if code := Code(err); code != "" {
+		fmt.Fprintf(w, "\ncode: %s", code)
+	}
+	if IsTemporary(err) {
+		io.WriteString(w, "\ntemporary: true")
+	}
+	if public := publicParts(err); len(public) > 0 {
+		fmt.Fprintf(w, "\npublic: %s", strings.Join(public, ", "))
+	}
+	keyvals := KeyVals(err)
+	for i := 0; i < len(keyvals); i += 2 {
+		fmt.Fprintf(w, "\n%v: %v", keyvals[i], keyvals[i+1])
+	}
+	if cs := StackTrace(err); len(cs) > 0 {
+		io.WriteString(w, "\nstack:")
+		writeStack(w, cs)
+	}
+}
+
+// publicParts returns the parts of err that are public.
+func publicParts(err error) []string {
+	var parts []string
+	err = cause(err)
+	if HasPublicMessage(err) {
+		parts = append(parts, "message")
+	}
+	if HasPublicStatusCode(err) {
+		parts = append(parts, "status")
+	}
+	if HasPublicCode(err) {
+		parts = append(parts, "code")
+	}
+	return parts
+}
+
+// writeStack writes the stack trace with one line for the function
+// and one line for the file and line number of each call.
+func writeStack(w io.Writer, cs stack.CallStack) {
+	for _, call := range cs {
+		fmt.Fprintf(w, "\n\t%+n\n\t\t%+v", call, call)
+	}
+}
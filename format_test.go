@@ -0,0 +1,98 @@
+package errkind
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestFormatVerbs(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "not found"},
+		{"%s", "not found"},
+		{"%q", `"not found"`},
+		{"%-12s|", "not found   |"},
+		{"%12v|", "   not found|"},
+		{"%.3s", "not"},
+		{"%x", "6e6f7420666f756e64"},
+		{"%X", "6E6F7420666F756E64"},
+		{"% x", "6e 6f 74 20 66 6f 75 6e 64"},
+		{"%#q", "`not found`"},
+	}
+	for _, err := range []error{NotFound(), NotFound().With(), WithStack(errors.New("not found"))} {
+		for _, tt := range tests {
+			if got, want := fmt.Sprintf(tt.format, err), tt.want; got != want {
+				t.Errorf("%s: want=%q, got=%q", tt.format, want, got)
+			}
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		err         error
+		wantVerbose string
+	}{
+		{
+			err:         NotFound(),
+			wantVerbose: "not found\nstatus: 404\npublic: status",
+		},
+		{
+			err:         Public("widget is locked", 409),
+			wantVerbose: "widget is locked\nstatus: 409\npublic: message, status",
+		},
+		{
+			err:         PublicWithCode("widget is locked", 409, "Locked"),
+			wantVerbose: "widget is locked code=Locked\nstatus: 409\ncode: Locked\npublic: message, status, code",
+		},
+		{
+			err:         NotFound().With("id", 1).With("password", "hunter2"),
+			wantVerbose: "not found id=1 password=[redacted]\nstatus: 404\npublic: status\nid: 1\npassword: [redacted]",
+		},
+		{
+			err:         Temporary("timeout"),
+			wantVerbose: "timeout\ntemporary: true",
+		},
+		{
+			err:         WithPublic(errors.New("db down").With("host", "db1", "password", Secret("x")), Public("unavailable", 503)),
+			wantVerbose: "db down host=db1 password=[redacted]\nstatus: 503\npublic: message, status\nhost: db1\npassword: [redacted]",
+		},
+		{
+			err:         WithStack(errors.New("not errkind").With("id", 1)),
+			wantVerbose: "not errkind id=1\nid: 1\nstack:\n",
+		},
+	}
+	for i, tt := range tests {
+		// %v and %s are the same as Error()
+		for _, verb := range []string{"%v", "%s"} {
+			if got, want := fmt.Sprintf(verb, tt.err), tt.err.Error(); got != want {
+				t.Errorf("%d: %s: want=%q, got=%q", i, verb, want, got)
+			}
+		}
+		if got, want := fmt.Sprintf("%q", tt.err), fmt.Sprintf("%q", tt.err.Error()); got != want {
+			t.Errorf("%d: %%q: want=%s, got=%s", i, want, got)
+		}
+		// errors in this package marshal using Encode
+		if data, err := json.Marshal(tt.err); err != nil {
+			t.Errorf("%d: %v", i, err)
+		} else if want, _ := Encode(tt.err); string(data) != string(want) {
+			t.Errorf("%d: json: want=%s, got=%s", i, want, data)
+		}
+		got := fmt.Sprintf("%+v", tt.err)
+		if strings.HasSuffix(tt.wantVerbose, "stack:\n") {
+			if !strings.HasPrefix(got, tt.wantVerbose) {
+				t.Errorf("%d:\nwant prefix=%q\n        got=%q", i, tt.wantVerbose, got)
+			}
+			continue
+		}
+		if want := tt.wantVerbose; got != want {
+			t.Errorf("%d:\nwant=%q\n got=%q", i, want, got)
+		}
+	}
+}
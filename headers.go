@@ -0,0 +1,144 @@
+package errkind
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultHeaders contains the headers that Headers includes for every
+// error, unless the error has its own values for the header. By default
+// it prevents error responses from being cached.
+//
+// DefaultHeaders should only be modified during program initialization.
+var DefaultHeaders = http.Header{
+	"Cache-Control": {"no-store"},
+}
+
+// headerer is an interface implemented by errors that have HTTP
+// headers to include in the response to a requesting client.
+type headerer interface {
+	Headers() http.Header
+}
+
+// Headers returns the headers set when the error was created.
+func (d *details) Headers() http.Header {
+	if d == nil {
+		return nil
+	}
+	return d.header
+}
+
+// SetHeader returns an option that sets a header of an error,
+// replacing any existing values for the header.
+//  err = errkind.WithOptions(err, errkind.SetHeader("Content-Language", "en"))
+func SetHeader(key, value string) Option {
+	return func(d *details) {
+		d.header = cloneHeader(d.header)
+		d.header.Set(key, value)
+	}
+}
+
+// AddHeader returns an option that adds a value to a header of an error,
+// keeping any existing values for the header.
+func AddHeader(key, value string) Option {
+	return func(d *details) {
+		d.header = cloneHeader(d.header)
+		d.header.Add(key, value)
+	}
+}
+
+// SetAllow returns an option that sets the Allow header of an error,
+// which lists the methods supported by the requested resource. The
+// methods are also available using AllowedMethods.
+func SetAllow(methods ...string) Option {
+	methods = append([]string(nil), methods...)
+	setHeader := SetHeader("Allow", strings.Join(methods, ", "))
+	return func(d *details) {
+		d.allowedMethods = methods
+		setHeader(d)
+	}
+}
+
+// SetRetryAfter returns an option that sets the Retry-After header of an
+// error, which indicates how long the client should wait before retrying.
+// The delay is rounded up to a whole number of seconds.
+func SetRetryAfter(delay time.Duration) Option {
+	seconds := (delay + time.Second - 1) / time.Second
+	if seconds < 0 {
+		seconds = 0
+	}
+	return SetHeader("Retry-After", strconv.FormatInt(int64(seconds), 10))
+}
+
+// SetLocation returns an option that sets the Location header of an error,
+// which identifies the URL that the client should use instead.
+func SetLocation(url string) Option {
+	return SetHeader("Location", url)
+}
+
+// Headers returns the HTTP headers that should be included in the response
+// to a requesting client for err. The headers of err and all of its causes
+// are merged: if more than one error in the chain of causes has values for
+// a header, the values closest to err are used. Because the headers are
+// returned to requesting clients, if err was created by WithPublic the headers
+// of its public error are used, and the headers of the masked error are not.
+// The headers in DefaultHeaders are included if no error in the chain has
+// values for them.
+//  for key, values := range errkind.Headers(err) {
+//      w.Header()[key] = values
+//  }
+//
+// Headers are attached to an error using options such as SetHeader and
+// SetRetryAfter. The WWW-Authenticate header is included for errors that
+// have authentication challenges (see Challenges). An error has headers if it,
+// or one of its causes, implements the following interface.
+//  type headerer interface {
+//      Headers() http.Header
+//  }
+//
+// The returned headers can be modified by the caller. If err is nil, Headers
+// returns nil. Otherwise the returned headers include DefaultHeaders, and are
+// only nil if DefaultHeaders is empty and no error in the chain has headers.
+func Headers(err error) http.Header {
+	if err == nil {
+		return nil
+	}
+	var merged http.Header
+	merge := func(h http.Header) {
+		for key, values := range h {
+			key = http.CanonicalHeaderKey(key)
+			if len(values) == 0 {
+				continue
+			}
+			if _, ok := merged[key]; ok {
+				continue
+			}
+			if merged == nil {
+				merged = make(http.Header)
+			}
+			merged[key] = append([]string(nil), values...)
+		}
+	}
+	walkPublic(err, func(err error) bool {
+		if h, ok := err.(headerer); ok {
+			merge(h.Headers())
+		}
+		return false
+	})
+	if challenges := Challenges(err); len(challenges) > 0 {
+		merge(http.Header{"Www-Authenticate": {FormatChallenges(challenges)}})
+	}
+	merge(DefaultHeaders)
+	return merged
+}
+
+// cloneHeader returns a copy of h, which may be nil.
+func cloneHeader(h http.Header) http.Header {
+	clone := make(http.Header, len(h))
+	for key, values := range h {
+		clone[key] = append([]string(nil), values...)
+	}
+	return clone
+}
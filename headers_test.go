@@ -0,0 +1,123 @@
+package errkind
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestHeaders(t *testing.T) {
+	inner := WithOptions(TemporaryWithStatus("busy", 503, SetRetryAfter(1500*time.Millisecond)), AddHeader("X-Reason", "busy"))
+	tests := []struct {
+		err  error
+		want http.Header
+	}{
+		{
+			err:  nil,
+			want: nil,
+		},
+		{
+			err:  errors.New("no headers"),
+			want: http.Header{"Cache-Control": {"no-store"}},
+		},
+		{
+			err: inner,
+			want: http.Header{
+				"Cache-Control": {"no-store"},
+				"Retry-After":   {"2"},
+				"X-Reason":      {"busy"},
+			},
+		},
+		{
+			err: WithOptions(errors.Wrap(inner, "wrapped"), SetRetryAfter(time.Minute), AddHeader("x-reason", "a"), AddHeader("X-Reason", "b")),
+			want: http.Header{
+				"Cache-Control": {"no-store"},
+				"Retry-After":   {"60"},
+				"X-Reason":      {"a", "b"},
+			},
+		},
+		{
+			err: WithOptions(NotFound(), SetLocation("/widgets/2"), SetHeader("Cache-Control", "max-age=60")),
+			want: http.Header{
+				"Cache-Control": {"max-age=60"},
+				"Location":      {"/widgets/2"},
+			},
+		},
+		{
+			err: WithOptions(BadRequest(), SetAllow("GET", "HEAD")),
+			want: http.Header{
+				"Allow":         {"GET, HEAD"},
+				"Cache-Control": {"no-store"},
+			},
+		},
+		{
+			err: WithPublic(WithOptions(errors.New("moved"), SetLocation("http://internal:8080/admin")), NotFound()),
+			want: http.Header{
+				"Cache-Control": {"no-store"},
+			},
+		},
+		{
+			err: WithOptions(WithPublic(errors.New("db busy"), WithOptions(ServiceUnavailable(), SetRetryAfter(time.Second))), SetHeader("X-Reason", "busy")),
+			want: http.Header{
+				"Cache-Control": {"no-store"},
+				"Retry-After":   {"1"},
+				"X-Reason":      {"busy"},
+			},
+		},
+		{
+			err: WithPublic(errors.New("signature invalid"), InvalidToken("example", "")),
+			want: http.Header{
+				"Cache-Control":    {"no-store"},
+				"Www-Authenticate": {`Bearer realm="example", error="invalid_token"`},
+			},
+		},
+		{
+			err: InvalidToken("example", ""),
+			want: http.Header{
+				"Cache-Control":    {"no-store"},
+				"Www-Authenticate": {`Bearer realm="example", error="invalid_token"`},
+			},
+		},
+		{
+			err: WithOptions(InvalidToken("example", ""), SetHeader("WWW-Authenticate", "Basic")),
+			want: http.Header{
+				"Cache-Control":    {"no-store"},
+				"Www-Authenticate": {"Basic"},
+			},
+		},
+		{
+			err: Join(
+				WithOptions(NotFound(), SetHeader("X-First", "1")),
+				WithOptions(BadRequest(), SetHeader("X-First", "2"), SetHeader("X-Second", "2")),
+			),
+			want: http.Header{
+				"Cache-Control": {"no-store"},
+				"X-First":       {"1"},
+				"X-Second":      {"2"},
+			},
+		},
+	}
+	for i, tt := range tests {
+		if got, want := Headers(tt.err), tt.want; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d:\nwant=%v\n got=%v", i, want, got)
+		}
+	}
+}
+
+func TestHeadersCopy(t *testing.T) {
+	err1 := WithOptions(NotFound(), SetHeader("X-Test", "1"))
+	err2 := WithOptions(err1, SetHeader("X-Test", "2"), AddHeader("X-Other", "2"))
+	Headers(err1)["X-Test"][0] = "modified"
+	if got, want := Headers(err1).Get("X-Test"), "1"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := Headers(err2).Get("X-Test"), "2"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := Headers(err1).Get("X-Other"), ""; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
@@ -0,0 +1,132 @@
+package errkind
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/jjeffery/errors"
+)
+
+// incidentIDer is an interface implemented by errors that have an incident ID.
+type incidentIDer interface {
+	IncidentID() string
+}
+
+// requestIDKey is the context key for the request ID.
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx with the request ID. Errors
+// stamped by WithIncident using the returned context have the request ID
+// as their incident ID.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// incidentError implements error, causer, keyvalser and incidentIDer interfaces.
+type incidentError struct {
+	err error
+	id  string
+}
+
+func (i incidentError) Error() string {
+	if strings.ContainsAny(i.id, "\n\r\t \"'=") {
+		return fmt.Sprintf("%s incident=%q", i.err.Error(), i.id)
+	}
+	return fmt.Sprintf("%s incident=%s", i.err.Error(), i.id)
+}
+
+func (i incidentError) Cause() error {
+	return i.err
+}
+
+func (i incidentError) Unwrap() error {
+	return i.err
+}
+
+func (i incidentError) IncidentID() string {
+	return i.id
+}
+
+func (i incidentError) Keyvals() []interface{} {
+	return []interface{}{"incident", i.id}
+}
+
+func (i incidentError) Format(f fmt.State, c rune) {
+	format(f, c, i)
+}
+
+func (i incidentError) MarshalJSON() ([]byte, error) {
+	return Encode(i)
+}
+
+func (i incidentError) With(keyvals ...interface{}) errors.Error {
+	return with(i, keyvals)
+}
+
+// WithIncident returns err stamped with an incident ID, which links the
+// error reported to a requesting client with the error that is logged.
+// If ctx has a request ID (see ContextWithRequestID), the request ID is the
+// incident ID. Otherwise the incident ID is a new random identifier.
+//
+// The incident ID is appended to the message of the returned error as a
+// key/value pair (eg "cannot connect incident=3f2a9c0d1b7e4a65"), and is
+// available using IncidentID. Because the incident ID does not contain any
+// implementation details, it is included in the view returned by PublicView,
+// even when the message is not public.
+//
+// If err already has an incident ID, it is returned unchanged. If err
+// is nil, WithIncident returns nil.
+func WithIncident(ctx context.Context, err error) errors.Error {
+	if err == nil {
+		return nil
+	}
+	if IncidentID(err) != "" {
+		if e, ok := err.(errors.Error); ok {
+			return e
+		}
+		return errors.Wrap(err)
+	}
+	var id string
+	if ctx != nil {
+		id, _ = ctx.Value(requestIDKey{}).(string)
+	}
+	if id == "" {
+		id = newIncidentID()
+	}
+	return incidentError{
+		err: err,
+		id:  id,
+	}
+}
+
+// IncidentID returns the incident ID of err or its causes, or a
+// blank string if err has not been stamped with an incident ID.
+//
+// An error has an incident ID if it, or one of its causes,
+// implements the following interface.
+//  type incidentIDer interface {
+//      IncidentID() string
+//  }
+func IncidentID(err error) string {
+	var id string
+	walk(err, func(err error) bool {
+		if i, ok := err.(incidentIDer); ok {
+			id = i.IncidentID()
+		}
+		return id != ""
+	})
+	return id
+}
+
+// newIncidentID returns a new random incident ID.
+func newIncidentID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// should never happen: the random source never returns an error
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,69 @@
+package errkind
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestWithIncident(t *testing.T) {
+	if got := WithIncident(context.Background(), nil); got != nil {
+		t.Errorf("want=nil, got=%v", got)
+	}
+
+	// random incident ID
+	err := WithIncident(context.Background(), errors.New("cannot connect"))
+	id := IncidentID(err)
+	if !regexp.MustCompile(`^[0-9a-f]{16}$`).MatchString(id) {
+		t.Errorf("unexpected incident ID: %q", id)
+	}
+	if got, want := err.Error(), "cannot connect incident="+id; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if other := IncidentID(WithIncident(context.TODO(), errors.New("cannot connect"))); other == id {
+		t.Errorf("want different incident IDs, got %v", other)
+	}
+
+	// incident ID from request ID
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+	tests := []struct {
+		err       error
+		wantError string
+		wantView  View
+	}{
+		{
+			err:       WithIncident(ctx, errors.New("cannot connect")),
+			wantError: "cannot connect incident=req-123",
+			wantView:  View{Status: 500, Message: "internal server error", Incident: "req-123"},
+		},
+		{
+			err:       errors.Wrap(WithIncident(ctx, NotFound()), "wrapped").With("id", 1),
+			wantError: "wrapped id=1: not found incident=req-123",
+			wantView:  View{Status: 404, Message: "not found", Incident: "req-123"},
+		},
+		{
+			err:       WithIncident(ctx, PublicWithCode("widget is locked", 409, "Locked")),
+			wantError: "widget is locked code=Locked incident=req-123",
+			wantView:  View{Status: 409, Code: "Locked", Message: "widget is locked", Incident: "req-123"},
+		},
+		{
+			// existing incident ID is retained
+			err:       WithIncident(ctx, WithIncident(ContextWithRequestID(ctx, "req 456"), Forbidden())),
+			wantError: `forbidden incident="req 456"`,
+			wantView:  View{Status: 403, Message: "forbidden", Incident: "req 456"},
+		},
+	}
+	for i, tt := range tests {
+		if got, want := tt.err.Error(), tt.wantError; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := PublicView(tt.err), tt.wantView; got != want {
+			t.Errorf("%d: want=%+v, got=%+v", i, want, got)
+		}
+		if got, want := KeyValMap(tt.err, FirstValue)["incident"], tt.wantView.Incident; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package errkind
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jjeffery/errors"
+)
+
+// codeHaser is an interface implemented by errors that have more
+// than one code, such as errors created by Join.
+type codeHaser interface {
+	HasCode(codes ...string) bool
+}
+
+// codeMatcher is implemented by errors in this package that have
+// more than one code, so that any of the codes can be matched.
+type codeMatcher interface {
+	matchCode(match func(code string) bool) bool
+}
+
+// StatusPolicy chooses the status code of an error created by Join from the
+// non-zero status codes of its errors, which are in the order the errors
+// were passed to Join. There is always at least one status code.
+type StatusPolicy func(statusCodes []int) int
+
+// MostSevereStatus is a StatusPolicy that chooses the first status code in
+// the highest class, so that server errors (5xx) take precedence over client
+// errors (4xx). It is the policy used by Join.
+func MostSevereStatus(statusCodes []int) int {
+	status := statusCodes[0]
+	for _, sc := range statusCodes[1:] {
+		if sc/100 > status/100 {
+			status = sc
+		}
+	}
+	return status
+}
+
+// MostCommonStatus is a StatusPolicy that chooses the status code that occurs
+// most often. If more than one status code occurs most often, the first of
+// them is chosen.
+func MostCommonStatus(statusCodes []int) int {
+	counts := make(map[int]int)
+	for _, sc := range statusCodes {
+		counts[sc]++
+	}
+	status := statusCodes[0]
+	for _, sc := range statusCodes {
+		if counts[sc] > counts[status] {
+			status = sc
+		}
+	}
+	return status
+}
+
+// joinError implements error, multiUnwrapper, statusCoder, coder,
+// codeHaser and temporaryer interfaces. It is used as a pointer, so
+// that errors created by Join can be compared.
+type joinError struct {
+	errs   []error
+	policy StatusPolicy
+	*details
+}
+
+func (j *joinError) Error() string {
+	msgs := make([]string, len(j.errs))
+	for i, err := range j.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (j *joinError) Unwrap() []error {
+	return j.errs
+}
+
+func (j *joinError) StatusCode() int {
+	var statusCodes []int
+	for _, err := range j.errs {
+		if sc := StatusCode(err); sc != 0 {
+			statusCodes = append(statusCodes, sc)
+		}
+	}
+	if len(statusCodes) == 0 {
+		return 0
+	}
+	return j.policy(statusCodes)
+}
+
+func (j *joinError) Code() string {
+	for _, err := range j.errs {
+		if code := Code(err); code != "" {
+			return code
+		}
+	}
+	return ""
+}
+
+func (j *joinError) HasCode(codes ...string) bool {
+	for _, err := range j.errs {
+		if HasCode(err, codes...) {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *joinError) matchCode(match func(code string) bool) bool {
+	for _, err := range j.errs {
+		if matchCode(err, match) {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *joinError) Temporary() bool {
+	for _, err := range j.errs {
+		if !IsTemporary(err) {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *joinError) Format(f fmt.State, c rune) {
+	format(f, c, j)
+}
+
+func (j *joinError) MarshalJSON() ([]byte, error) {
+	return Encode(j)
+}
+
+func (j *joinError) With(keyvals ...interface{}) errors.Error {
+	return with(j, keyvals)
+}
+
+func (j *joinError) withDetails(fn func(*details)) errors.Error {
+	clone := *j
+	clone.details = j.details.clone()
+	fn(clone.details)
+	return &clone
+}
+
+// Join returns an error that combines errs, such as the errors from work that
+// has been fanned out. Nil errors are discarded, and Join returns nil if
+// there are no errors left.
+//
+// The returned error reports on the combined errors as follows:
+//  StatusCode   the status code chosen by MostSevereStatus
+//  Code         the first code of the errors
+//  HasCode      true if any of the errors has one of the codes, and the same
+//               for HasCodeFold, HasCodePrefix and HasCodePrefixFold
+//  IsTemporary  true if all of the errors are temporary
+//
+// The message of the returned error contains the messages of the errors,
+// separated by semicolons. Its Unwrap method returns the errors, so that
+// KeyVals, As and All report on all of them. The returned error is never
+// public, because the errors may be a mixture of public and internal errors.
+func Join(errs ...error) errors.Error {
+	return join(MostSevereStatus, errs)
+}
+
+// JoinWithPolicy is the same as Join, except that the status code of the
+// returned error is chosen by policy. If policy is nil, MostSevereStatus
+// is used.
+//  err := errkind.JoinWithPolicy(errkind.MostCommonStatus, errs...)
+func JoinWithPolicy(policy StatusPolicy, errs ...error) errors.Error {
+	return join(policy, errs)
+}
+
+func join(policy StatusPolicy, errs []error) errors.Error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	if policy == nil {
+		policy = MostSevereStatus
+	}
+	return &joinError{
+		errs:    nonNil,
+		policy:  policy,
+		details: newDetails(2),
+	}
+}
@@ -0,0 +1,181 @@
+package errkind
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		errs       []error
+		policy     StatusPolicy
+		nilErr     bool
+		errText    string
+		status     int
+		code       string
+		hasCode    []string
+		notHasCode []string
+		temporary  bool
+	}{
+		{
+			errs:   nil,
+			nilErr: true,
+		},
+		{
+			errs:   []error{nil, nil},
+			nilErr: true,
+		},
+		{
+			errs:    []error{errors.New("first"), nil, errors.New("second")},
+			errText: "first; second",
+		},
+		{
+			errs:       []error{NotFound(), errors.Wrap(Public("cannot connect", 503), "wrapped"), BadRequest()},
+			errText:    "not found; wrapped: cannot connect; bad request",
+			status:     503,
+			notHasCode: []string{""},
+		},
+		{
+			errs:    []error{NotFound(), BadRequest(), Forbidden()},
+			errText: "not found; bad request; forbidden",
+			status:  404,
+		},
+		{
+			errs:    []error{NotFound(), BadRequest(), BadRequest(), errors.New("no status")},
+			policy:  MostCommonStatus,
+			errText: "not found; bad request; bad request; no status",
+			status:  400,
+		},
+		{
+			errs:    []error{Forbidden(), NotFound(), NotFound(), Forbidden()},
+			policy:  MostCommonStatus,
+			errText: "forbidden; not found; not found; forbidden",
+			status:  403,
+		},
+		{
+			errs:       []error{errors.New("no code"), PublicWithCode("locked", 409, "Locked"), PublicWithCode("gone", 410, "Gone")},
+			errText:    "no code; locked code=Locked; gone code=Gone",
+			status:     409,
+			code:       "Locked",
+			hasCode:    []string{"Locked", "Gone"},
+			notHasCode: []string{"Missing", ""},
+		},
+		{
+			errs:      []error{Temporary("timeout"), errors.Wrap(Temporary("busy"), "wrapped")},
+			errText:   "timeout; wrapped: busy",
+			temporary: true,
+		},
+		{
+			errs:      []error{Temporary("timeout"), errors.New("permanent")},
+			errText:   "timeout; permanent",
+			temporary: false,
+		},
+		{
+			errs:      []error{Join(NotFound(), Temporary("timeout")), PublicWithCode("locked", 409, "Locked")},
+			errText:   "not found; timeout; locked code=Locked",
+			status:    404,
+			code:      "Locked",
+			hasCode:   []string{"Locked"},
+			temporary: false,
+		},
+	}
+	for i, tt := range tests {
+		var err error
+		if tt.policy == nil {
+			err = Join(tt.errs...)
+		} else {
+			err = JoinWithPolicy(tt.policy, tt.errs...)
+		}
+		if tt.nilErr {
+			if err != nil {
+				t.Errorf("%d: want=nil, got=%v", i, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%d: want=non-nil, got=nil", i)
+			continue
+		}
+		if got, want := err.Error(), tt.errText; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		for _, wrapped := range []error{err, errors.Wrap(err, "wrapped")} {
+			if got, want := StatusCode(wrapped), tt.status; got != want {
+				t.Errorf("%d: want=%v, got=%v", i, want, got)
+			}
+			if got, want := Code(wrapped), tt.code; got != want {
+				t.Errorf("%d: want=%v, got=%v", i, want, got)
+			}
+			for _, code := range tt.hasCode {
+				if !HasCode(wrapped, code) {
+					t.Errorf("%d: want HasCode(%q)", i, code)
+				}
+			}
+			for _, code := range tt.notHasCode {
+				if HasCode(wrapped, code) {
+					t.Errorf("%d: want !HasCode(%q)", i, code)
+				}
+			}
+			if got, want := IsTemporary(wrapped), tt.temporary; got != want {
+				t.Errorf("%d: want=%v, got=%v", i, want, got)
+			}
+		}
+	}
+}
+
+func TestJoinKeyVals(t *testing.T) {
+	err := Join(
+		NotFound().With("id", 1),
+		errors.Wrap(BadRequest(), "wrapped").With("name", "x"),
+	).With("batch", 7)
+	want := []interface{}{"batch", 7, "id", 1, "name", "x"}
+	if got := KeyVals(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
+
+func TestJoinCodeFamilies(t *testing.T) {
+	err := Join(
+		PublicWithCode("a", 400, "x.y"),
+		PublicWithCode("b", 400, "auth.token.expired"),
+	)
+	for _, wrapped := range []error{err, errors.Wrap(err, "wrapped")} {
+		if !HasCodePrefix(wrapped, "auth.token") {
+			t.Error("want HasCodePrefix")
+		}
+		if !HasCodePrefixFold(wrapped, "AUTH.TOKEN") {
+			t.Error("want HasCodePrefixFold")
+		}
+		if !HasCodeFold(wrapped, "Auth.Token.Expired") {
+			t.Error("want HasCodeFold")
+		}
+		if !Match(wrapped, MatchCodePrefix("auth")) {
+			t.Error("want MatchCodePrefix")
+		}
+		if HasCodePrefix(wrapped, "billing") {
+			t.Error("want !HasCodePrefix")
+		}
+	}
+}
+
+func TestJoinWithNilPolicy(t *testing.T) {
+	err := JoinWithPolicy(nil, BadRequest(), ServiceUnavailable())
+	if got, want := StatusCode(err), 503; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
+
+func TestJoinCompare(t *testing.T) {
+	a := Join(NotFound(), BadRequest())
+	if b := error(a); a != b {
+		t.Error("want equal")
+	}
+	if c := Join(NotFound(), BadRequest()); a == c {
+		t.Error("want not equal")
+	}
+	if c := WithOptions(a, SetSeverity(SeverityCritical)); a == c || Severity(a) == SeverityCritical {
+		t.Error("want copy with options")
+	}
+}
@@ -0,0 +1,313 @@
+package errkind
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jjeffery/errors"
+)
+
+// jsonError is the JSON representation of an error.
+type jsonError struct {
+	Error     string        `json:"error"`
+	Message   string        `json:"message,omitempty"`
+	Status    int           `json:"status,omitempty"`
+	Code      string        `json:"code,omitempty"`
+	Temporary bool          `json:"temporary,omitempty"`
+	Public    []string      `json:"public,omitempty"`
+	Wrapped   bool          `json:"wrapped,omitempty"`
+	Incident  string        `json:"incident,omitempty"`
+	KeyVals   []interface{} `json:"keyvals,omitempty"`
+}
+
+// Encode returns the JSON encoding of err. The encoding contains the error
+// message, status code, code, temporary flag, which of the message, status code
+// and code are public, whether err was wrapped (so that it is not public itself,
+// although its cause is), the incident ID and the key/value pairs of err and its
+// causes. Use Decode to rebuild the error.
+//  {
+//    "error": "cannot update widget id=42: widget is locked code=Locked",
+//    "message": "widget is locked",
+//    "status": 409,
+//    "code": "Locked",
+//    "public": ["message", "status", "code"],
+//    "wrapped": true,
+//    "keyvals": ["id", 42]
+//  }
+// The encoding is intended for passing errors between services and through
+// queues. It contains all of the details of the error, so it should not be
+// returned to requesting clients: use PublicView instead.
+//
+// Errors created by this package implement the json.Marshaler interface using
+// Encode. Use JSONError to marshal and unmarshal other errors as part of a
+// larger JSON document.
+//
+// If err is nil, Encode returns the JSON null value.
+func Encode(err error) ([]byte, error) {
+	if err == nil {
+		return []byte("null"), nil
+	}
+	je := jsonError{
+		Error:     err.Error(),
+		Status:    StatusCode(err),
+		Code:      Code(err),
+		Temporary: IsTemporary(err),
+		Public:    publicParts(err),
+		Incident:  IncidentID(err),
+	}
+	if len(je.Public) > 0 {
+		je.Wrapped = !HasPublicMessage(err) && !HasPublicStatusCode(err) && !HasPublicCode(err)
+	}
+	if c := cause(err); c != nil {
+		if m, ok := c.(messager); ok {
+			je.Message = m.Message()
+		} else {
+			je.Message = c.Error()
+		}
+	}
+	keyvals := KeyVals(err)
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		value := keyvals[i+1]
+		if _, err := json.Marshal(value); err != nil {
+			value = fmt.Sprint(value)
+		}
+		je.KeyVals = append(je.KeyVals, key, value)
+	}
+	return json.Marshal(je)
+}
+
+// Decode rebuilds an error from its JSON encoding (see Encode). The rebuilt
+// error has the same message as the original error, and behaves the same
+// way with StatusCode, Code, HasCode, IsTemporary, HasPublicMessage,
+// HasPublicStatusCode, HasPublicCode, PublicView, IncidentID and KeyVals.
+//
+// Public markers are restored for the combinations of public message, status
+// code and code created by the constructors in this package. For any other
+// combination, the public markers are not restored, so that no detail is made
+// public that was not public in the original error.
+//
+// If data is the JSON null value, Decode returns nil. If data cannot be decoded,
+// Decode returns an error describing the problem.
+func Decode(data []byte) error {
+	var je *jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return errors.Wrap(err, "cannot decode error")
+	}
+	if je == nil {
+		return nil
+	}
+	return je.decode()
+}
+
+// decode returns the error described by je. If the original error was
+// wrapped, the decoded error is also wrapped, so that it is not public itself
+// although its cause is.
+func (je *jsonError) decode() error {
+	if je.Wrapped {
+		return decodedWrapper{
+			message:  je.Error,
+			incident: je.Incident,
+			keyvals:  je.KeyVals,
+			cause:    je.decodeKind(decodedError{message: je.Message}),
+		}
+	}
+	return je.decodeKind(decodedError{
+		message:  je.Error,
+		incident: je.Incident,
+		keyvals:  je.KeyVals,
+	})
+}
+
+// decodeKind completes d with the kind of error described by je, and
+// returns it with a public error if it has public markers.
+func (je *jsonError) decodeKind(d decodedError) error {
+	d.text = je.Message
+	d.status = je.Status
+	d.code = je.Code
+	d.temporary = je.Temporary
+
+	public := make(map[string]bool)
+	for _, p := range je.Public {
+		public[p] = true
+	}
+	switch {
+	case public["message"] && public["status"] && public["code"] && je.Code != "":
+		return publicDecodedError{
+			decodedError: d,
+			public: publicStatusCodeError{
+				message: je.Message,
+				status:  je.Status,
+				code:    je.Code,
+			},
+		}
+	case public["message"] && public["status"] && !public["code"] && je.Code == "":
+		return publicDecodedError{
+			decodedError: d,
+			public: publicStatusError{
+				statusError{
+					message: je.Message,
+					status:  je.Status,
+				},
+			},
+		}
+	case !public["message"] && public["status"] && !public["code"] && je.Code == "":
+		return publicDecodedError{
+			decodedError: d,
+			public: statusError{
+				message: je.Message,
+				status:  je.Status,
+			},
+		}
+	}
+	return d
+}
+
+// decodedError is an error rebuilt by Decode. It implements error, statusCoder,
+// coder, temporaryer, incidentIDer and keyvalser interfaces.
+type decodedError struct {
+	message   string
+	text      string
+	status    int
+	code      string
+	temporary bool
+	incident  string
+	keyvals   []interface{}
+}
+
+func (d decodedError) Error() string {
+	return d.message
+}
+
+func (d decodedError) Message() string {
+	return d.text
+}
+
+func (d decodedError) StatusCode() int {
+	return d.status
+}
+
+func (d decodedError) Code() string {
+	return d.code
+}
+
+func (d decodedError) Temporary() bool {
+	return d.temporary
+}
+
+func (d decodedError) IncidentID() string {
+	return d.incident
+}
+
+func (d decodedError) Keyvals() []interface{} {
+	return d.keyvals
+}
+
+func (d decodedError) Format(f fmt.State, c rune) {
+	format(f, c, d)
+}
+
+func (d decodedError) MarshalJSON() ([]byte, error) {
+	return Encode(d)
+}
+
+func (d decodedError) With(keyvals ...interface{}) errors.Error {
+	return with(d, keyvals)
+}
+
+// publicDecodedError is an error rebuilt by Decode that has a public error,
+// which has the public markers of the original error.
+type publicDecodedError struct {
+	decodedError
+	public error
+}
+
+func (d publicDecodedError) PublicError() error {
+	return d.public
+}
+
+func (d publicDecodedError) Format(f fmt.State, c rune) {
+	format(f, c, d)
+}
+
+func (d publicDecodedError) MarshalJSON() ([]byte, error) {
+	return Encode(d)
+}
+
+func (d publicDecodedError) With(keyvals ...interface{}) errors.Error {
+	return with(d, keyvals)
+}
+
+// decodedWrapper is an error rebuilt by Decode from an error that was wrapped.
+// It implements error, causer, incidentIDer and keyvalser interfaces.
+type decodedWrapper struct {
+	message  string
+	incident string
+	keyvals  []interface{}
+	cause    error
+}
+
+func (d decodedWrapper) Error() string {
+	return d.message
+}
+
+func (d decodedWrapper) Cause() error {
+	return d.cause
+}
+
+func (d decodedWrapper) Unwrap() error {
+	return d.cause
+}
+
+func (d decodedWrapper) IncidentID() string {
+	return d.incident
+}
+
+func (d decodedWrapper) Keyvals() []interface{} {
+	return d.keyvals
+}
+
+func (d decodedWrapper) Format(f fmt.State, c rune) {
+	format(f, c, d)
+}
+
+func (d decodedWrapper) MarshalJSON() ([]byte, error) {
+	return Encode(d)
+}
+
+func (d decodedWrapper) With(keyvals ...interface{}) errors.Error {
+	return with(d, keyvals)
+}
+
+// JSONError contains an error that can be marshaled to and unmarshaled from
+// JSON using Encode and Decode. Use it for errors that are fields in a larger
+// JSON document.
+//  type Result struct {
+//      ID  string            `json:"id"`
+//      Err errkind.JSONError `json:"err"`
+//  }
+type JSONError struct {
+	Err error
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (j JSONError) MarshalJSON() ([]byte, error) {
+	return Encode(j.Err)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (j *JSONError) UnmarshalJSON(data []byte) error {
+	var je *jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+	if je == nil {
+		j.Err = nil
+		return nil
+	}
+	j.Err = je.decode()
+	return nil
+}
@@ -0,0 +1,176 @@
+package errkind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	tests := []struct {
+		err      error
+		wantJSON string
+	}{
+		{
+			err:      NotFound(),
+			wantJSON: `{"error":"not found","message":"not found","status":404,"public":["status"]}`,
+		},
+		{
+			err:      Public("widget is locked", 409),
+			wantJSON: `{"error":"widget is locked","message":"widget is locked","status":409,"public":["message","status"]}`,
+		},
+		{
+			err:      PublicWithCode("widget is locked", 409, "Locked"),
+			wantJSON: `{"error":"widget is locked code=Locked","message":"widget is locked","status":409,"code":"Locked","public":["message","status","code"]}`,
+		},
+		{
+			err:      errors.Wrap(PublicWithCode("widget is locked", 409, "Locked"), "cannot update").With("id", 42),
+			wantJSON: `{"error":"cannot update id=42: widget is locked code=Locked","message":"widget is locked","status":409,"code":"Locked","public":["message","status","code"],"wrapped":true,"keyvals":["id",42]}`,
+		},
+		{
+			err:      fmt.Errorf("get widget: %w", PublicWithCode("widget locked", 409, "Locked")),
+			wantJSON: `{"error":"get widget: widget locked code=Locked","message":"widget locked","status":409,"code":"Locked","public":["message","status","code"],"wrapped":true}`,
+		},
+		{
+			err:      Temporary("timeout").With("password", "xyzzy"),
+			wantJSON: `{"error":"timeout password=[redacted]","message":"timeout","temporary":true,"keyvals":["password","[redacted]"]}`,
+		},
+		{
+			err:      WithPublic(Temporary("timeout"), Public("try again", 503)),
+			wantJSON: `{"error":"timeout","message":"try again","status":503,"temporary":true,"public":["message","status"]}`,
+		},
+		{
+			err:      WithIncident(ctx, errors.New("cannot connect").With("ch", make(chan int))),
+			wantJSON: "",
+		},
+		{
+			err:      testingStatusError(418),
+			wantJSON: `{"error":"testing status error","message":"testing status error","status":418}`,
+		},
+	}
+	for i, tt := range tests {
+		data, err := Encode(tt.err)
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if tt.wantJSON != "" {
+			if got, want := string(data), tt.wantJSON; got != want {
+				t.Errorf("%d:\nwant=%v\n got=%v", i, want, got)
+			}
+		}
+
+		decoded := Decode(data)
+		checkSameError(t, i, tt.err, decoded)
+
+		// encoding the decoded error gives the same result
+		data2, err := json.Marshal(decoded)
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if got, want := string(data2), string(data); got != want {
+			t.Errorf("%d:\nwant=%v\n got=%v", i, want, got)
+		}
+	}
+}
+
+// checkSameError checks that err2 behaves the same way as err1.
+func checkSameError(t *testing.T, i int, err1, err2 error) {
+	t.Helper()
+	if got, want := err2.Error(), err1.Error(); got != want {
+		t.Errorf("%d: Error: want=%v, got=%v", i, want, got)
+	}
+	if got, want := StatusCode(err2), StatusCode(err1); got != want {
+		t.Errorf("%d: StatusCode: want=%v, got=%v", i, want, got)
+	}
+	if got, want := Code(err2), Code(err1); got != want {
+		t.Errorf("%d: Code: want=%v, got=%v", i, want, got)
+	}
+	if got, want := IsTemporary(err2), IsTemporary(err1); got != want {
+		t.Errorf("%d: IsTemporary: want=%v, got=%v", i, want, got)
+	}
+	if got, want := HasPublicMessage(err2), HasPublicMessage(err1); got != want {
+		t.Errorf("%d: HasPublicMessage: want=%v, got=%v", i, want, got)
+	}
+	if got, want := HasPublicStatusCode(err2), HasPublicStatusCode(err1); got != want {
+		t.Errorf("%d: HasPublicStatusCode: want=%v, got=%v", i, want, got)
+	}
+	if got, want := HasPublicCode(err2), HasPublicCode(err1); got != want {
+		t.Errorf("%d: HasPublicCode: want=%v, got=%v", i, want, got)
+	}
+	if got, want := PublicView(err2), PublicView(err1); got != want {
+		t.Errorf("%d: PublicView: want=%+v, got=%+v", i, want, got)
+	}
+	if got, want := IncidentID(err2), IncidentID(err1); got != want {
+		t.Errorf("%d: IncidentID: want=%v, got=%v", i, want, got)
+	}
+	if got, want := fmt.Sprint(KeyVals(err2)), fmt.Sprint(KeyVals(err1)); got != want {
+		t.Errorf("%d: KeyVals: want=%v, got=%v", i, want, got)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	if err := Decode([]byte("null")); err != nil {
+		t.Errorf("want=nil, got=%v", err)
+	}
+	if err := Decode([]byte("{")); err == nil {
+		t.Errorf("want error, got nil")
+	}
+
+	// unsupported combination of public markers is not restored
+	err := Decode([]byte(`{"error":"msg","message":"msg","status":400,"code":"X","public":["message","status"]}`))
+	if HasPublicMessage(err) || HasPublicStatusCode(err) {
+		t.Errorf("want not public")
+	}
+	if got, want := PublicView(err), DefaultView; got != want {
+		t.Errorf("want=%+v, got=%+v", want, got)
+	}
+}
+
+func TestJSONError(t *testing.T) {
+	type result struct {
+		ID  string    `json:"id"`
+		Err JSONError `json:"err"`
+	}
+	tests := []struct {
+		r        result
+		wantJSON string
+	}{
+		{
+			r:        result{ID: "1"},
+			wantJSON: `{"id":"1","err":null}`,
+		},
+		{
+			r:        result{ID: "2", Err: JSONError{Err: NotFound().With("id", "2")}},
+			wantJSON: `{"id":"2","err":{"error":"not found id=2","message":"not found","status":404,"public":["status"],"wrapped":true,"keyvals":["id","2"]}}`,
+		},
+	}
+	for i, tt := range tests {
+		data, err := json.Marshal(tt.r)
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if got, want := string(data), tt.wantJSON; got != want {
+			t.Errorf("%d:\nwant=%v\n got=%v", i, want, got)
+		}
+		var r result
+		r.Err.Err = errors.New("should be overwritten")
+		if err := json.Unmarshal(data, &r); err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if tt.r.Err.Err == nil {
+			if r.Err.Err != nil {
+				t.Errorf("%d: want=nil, got=%v", i, r.Err.Err)
+			}
+			continue
+		}
+		checkSameError(t, i, tt.r.Err.Err, r.Err.Err)
+	}
+}
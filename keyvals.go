@@ -0,0 +1,88 @@
+package errkind
+
+import "fmt"
+
+// keyvalser is an interface implemented by errors that have key/value
+// pairs attached. Errors created by the github.com/jjeffery/errors
+// package implement this interface.
+type keyvalser interface {
+	Keyvals() []interface{}
+}
+
+// KeyVals returns all of the key/value pairs attached to err and its causes,
+// in order from the outermost error to the innermost error. The chain of causes
+// is followed in the same way as As and All: using the Cause method, or the Unwrap
+// method for errors that do not have a Cause method. Each error in the chain contributes key/value pairs if it
+// implements the following interface, which is implemented by errors created by
+// the github.com/jjeffery/errors package.
+//  type keyvalser interface {
+//      Keyvals() []interface{}
+//  }
+//
+// Values whose key is in RedactedKeys are returned as secret values (see Secret).
+// All key/value pairs are returned, even if the same key appears more than once
+// in the chain. Use KeyValMap to resolve duplicate keys. If an error in the chain
+// has an odd number of key/value items, the missing value is nil.
+func KeyVals(err error) []interface{} {
+	var keyvals []interface{}
+	walk(err, func(err error) bool {
+		if kv, ok := err.(keyvalser); ok {
+			keyvals = append(keyvals, redact(kv.Keyvals())...)
+			if len(keyvals)%2 != 0 {
+				keyvals = append(keyvals, nil)
+			}
+		}
+		return false
+	})
+	return keyvals
+}
+
+// DuplicateKeys determines how KeyValMap handles a key that
+// appears more than once in the chain of causes.
+type DuplicateKeys int
+
+// Values for DuplicateKeys.
+const (
+	// FirstValue uses the value from the outermost error.
+	FirstValue DuplicateKeys = iota
+
+	// LastValue uses the value from the innermost error.
+	LastValue
+
+	// AllValues uses a []interface{} containing every value for the key,
+	// from the outermost error to the innermost error. Keys that appear only
+	// once have a single-element slice.
+	AllValues
+)
+
+// KeyValMap returns the key/value pairs attached to err and its causes as a
+// map. Keys that are not strings are converted using fmt.Sprint. The dup
+// policy determines the value for keys that appear more than once.
+//
+// KeyValMap returns nil if there are no key/value pairs.
+func KeyValMap(err error, dup DuplicateKeys) map[string]interface{} {
+	keyvals := KeyVals(err)
+	if len(keyvals) == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, len(keyvals)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		value := keyvals[i+1]
+		switch dup {
+		case AllValues:
+			values, _ := m[key].([]interface{})
+			m[key] = append(values, value)
+		case LastValue:
+			m[key] = value
+		default:
+			if _, ok := m[key]; !ok {
+				m[key] = value
+			}
+		}
+	}
+	return m
+}
@@ -0,0 +1,84 @@
+package errkind
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+// testingUnwrapError wraps an error using the Unwrap convention.
+type testingUnwrapError struct {
+	err error
+}
+
+func (e testingUnwrapError) Error() string {
+	return "unwrap: " + e.err.Error()
+}
+
+func (e testingUnwrapError) Unwrap() error {
+	return e.err
+}
+
+func TestKeyVals(t *testing.T) {
+	inner := NotFound().With("id", 1, "table", "widgets")
+	outer := errors.Wrap(inner, "cannot get widget").With("id", 2, "user", "bob")
+	tests := []struct {
+		err       error
+		want      []interface{}
+		wantFirst map[string]interface{}
+		wantLast  map[string]interface{}
+		wantAll   map[string]interface{}
+	}{
+		{
+			err: nil,
+		},
+		{
+			err: errors.New("no keyvals"),
+		},
+		{
+			err:       inner,
+			want:      []interface{}{"id", 1, "table", "widgets"},
+			wantFirst: map[string]interface{}{"id": 1, "table": "widgets"},
+			wantLast:  map[string]interface{}{"id": 1, "table": "widgets"},
+			wantAll:   map[string]interface{}{"id": []interface{}{1}, "table": []interface{}{"widgets"}},
+		},
+		{
+			err:       outer,
+			want:      []interface{}{"id", 2, "user", "bob", "id", 1, "table", "widgets"},
+			wantFirst: map[string]interface{}{"id": 2, "user": "bob", "table": "widgets"},
+			wantLast:  map[string]interface{}{"id": 1, "user": "bob", "table": "widgets"},
+			wantAll:   map[string]interface{}{"id": []interface{}{2, 1}, "user": []interface{}{"bob"}, "table": []interface{}{"widgets"}},
+		},
+		{
+			// traverses errors that implement Unwrap
+			err:       testingUnwrapError{err: errors.New("x").With(3, "three", "n")},
+			want:      []interface{}{3, "three", "n", nil},
+			wantFirst: map[string]interface{}{"3": "three", "n": nil},
+			wantLast:  map[string]interface{}{"3": "three", "n": nil},
+			wantAll:   map[string]interface{}{"3": []interface{}{"three"}, "n": []interface{}{nil}},
+		},
+		{
+			// traverses past a public error
+			err:       WithPublic(errors.New("internal").With("host", "db"), NotFound()).With("user", "bob"),
+			want:      []interface{}{"user", "bob", "host", "db"},
+			wantFirst: map[string]interface{}{"user": "bob", "host": "db"},
+			wantLast:  map[string]interface{}{"user": "bob", "host": "db"},
+			wantAll:   map[string]interface{}{"user": []interface{}{"bob"}, "host": []interface{}{"db"}},
+		},
+	}
+	for i, tt := range tests {
+		if got, want := KeyVals(tt.err), tt.want; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := KeyValMap(tt.err, FirstValue), tt.wantFirst; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := KeyValMap(tt.err, LastValue), tt.wantLast; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := KeyValMap(tt.err, AllValues), tt.wantAll; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package errkind
+
+import (
+	"fmt"
+
+	"github.com/jjeffery/errors"
+)
+
+// maskedError implements error, causer and publicErrorer interfaces.
+type maskedError struct {
+	err    error
+	public error
+	*details
+}
+
+func (m maskedError) Error() string {
+	return m.err.Error()
+}
+
+func (m maskedError) Cause() error {
+	return m.err
+}
+
+func (m maskedError) Unwrap() error {
+	return m.err
+}
+
+func (m maskedError) PublicError() error {
+	return m.public
+}
+
+func (m maskedError) Format(f fmt.State, c rune) {
+	format(f, c, m)
+}
+
+func (m maskedError) MarshalJSON() ([]byte, error) {
+	return Encode(m)
+}
+
+func (m maskedError) With(keyvals ...interface{}) errors.Error {
+	return with(m, keyvals)
+}
+
+func (m maskedError) withDetails(fn func(*details)) errors.Error {
+	m.details = m.details.clone()
+	fn(m.details)
+	return m
+}
+
+// WithPublic returns an error that presents the public error to requesting
+// clients, while retaining err for logging.
+//
+// The Error method of the returned error returns the message of err, so
+// that the internal details are available for logging. The returned error's
+// Cause method returns err, so errors.Cause will return the cause of err.
+// The HasPublicMessage, HasPublicStatusCode, HasPublicCode, StatusCode,
+// Code, HasCode and PublicView functions all report on the public error.
+// IsTemporary reports on err, because whether an operation can be retried
+// depends on the underlying error condition.
+//
+// Unlike errors created by Public and PublicWithCode, the public error is
+// not lost if the returned error is wrapped, or if key/value pairs are
+// attached using the With method: PublicView, StatusCode and Code continue
+// to report on the public error.
+//  err = errkind.WithPublic(err, errkind.Public("service unavailable", 503))
+//  return err.With("userID", userID)
+//
+// If err is nil, WithPublic returns nil. If public is nil, the returned error
+// has no public error.
+func WithPublic(err error, public error) errors.Error {
+	return mask(err, public)
+}
+
+// Mask is the same as WithPublic: it returns an error that presents the
+// public error to requesting clients, while retaining err for logging.
+//  return errkind.Mask(err, errkind.NotFound())
+func Mask(err error, public error) errors.Error {
+	return mask(err, public)
+}
+
+// mask implements WithPublic and Mask. The stack trace, if
+// captured, starts at the caller of WithPublic or Mask.
+func mask(err error, public error) errors.Error {
+	if err == nil {
+		return nil
+	}
+	if public == nil {
+		return errors.Wrap(err)
+	}
+	return maskedError{
+		err:     err,
+		public:  public,
+		details: newDetails(2),
+	}
+}
@@ -0,0 +1,90 @@
+package errkind
+
+import (
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestWithPublic(t *testing.T) {
+	internal := errors.New("cannot connect to database").With("host", "10.0.0.1")
+	tests := []struct {
+		err           error
+		wantError     string
+		wantStatus    int
+		wantCode      string
+		wantPublic    bool
+		wantTemporary bool
+		wantView      View
+	}{
+		{
+			err:        WithPublic(internal, PublicWithCode("service unavailable", 503, "Unavailable")),
+			wantError:  "cannot connect to database host=10.0.0.1",
+			wantStatus: 503,
+			wantCode:   "Unavailable",
+			wantPublic: true,
+			wantView:   View{Status: 503, Code: "Unavailable", Message: "service unavailable"},
+		},
+		{
+			err:        Mask(internal, PublicWithCode("service unavailable", 503, "Unavailable")),
+			wantError:  "cannot connect to database host=10.0.0.1",
+			wantStatus: 503,
+			wantCode:   "Unavailable",
+			wantPublic: true,
+			wantView:   View{Status: 503, Code: "Unavailable", Message: "service unavailable"},
+		},
+		{
+			err:        WithPublic(internal, Public("service unavailable", 503)).With("user", "bob"),
+			wantError:  "cannot connect to database host=10.0.0.1 user=bob",
+			wantStatus: 503,
+			wantView:   View{Status: 503, Message: "service unavailable"},
+		},
+		{
+			err:        errors.Wrap(WithPublic(NotFound(), Forbidden()), "cannot get widget"),
+			wantError:  "cannot get widget: not found",
+			wantStatus: 403,
+			wantView:   View{Status: 403, Message: "forbidden"},
+		},
+		{
+			err:           WithPublic(Temporary("timeout"), Public("try again", 503)),
+			wantError:     "timeout",
+			wantStatus:    503,
+			wantPublic:    true,
+			wantTemporary: true,
+			wantView:      View{Status: 503, Message: "try again"},
+		},
+		{
+			err:        WithPublic(NotFound("no such table"), nil),
+			wantError:  "no such table",
+			wantStatus: 404,
+			wantView:   View{Status: 404, Message: "not found"},
+		},
+	}
+	for i, tt := range tests {
+		if got, want := tt.err.Error(), tt.wantError; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := StatusCode(tt.err), tt.wantStatus; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := Code(tt.err), tt.wantCode; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := HasPublicMessage(tt.err), tt.wantPublic; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := IsTemporary(tt.err), tt.wantTemporary; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := PublicView(tt.err), tt.wantView; got != want {
+			t.Errorf("%d: want=%+v, got=%+v", i, want, got)
+		}
+	}
+
+	if got := WithPublic(nil, NotFound()); got != nil {
+		t.Errorf("want=nil, got=%v", got)
+	}
+	if got, want := errors.Cause(WithPublic(internal, NotFound())), internal; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
@@ -0,0 +1,162 @@
+package errkind
+
+import "reflect"
+
+// Matcher reports whether an error matches some condition. Matchers are
+// created by the MatchXxx functions in this package and combined using And,
+// Or and Not. Any function with the same signature can be used as a Matcher.
+//  notFound := errkind.Or(errkind.MatchStatus(404), errkind.MatchCode("NoSuchKey"))
+//  if errkind.Match(err, notFound) {
+//      // ...
+//  }
+//
+// The matchers created by this package consider the whole chain of causes of
+// the error, in the same way as the corresponding functions (eg MatchStatus
+// uses HasStatusCode), so they give the same answer for an error and the
+// same error wrapped with additional context. They do not match a nil error.
+type Matcher func(err error) bool
+
+// Match reports whether err matches any of the matchers.
+// Use And to require all of the matchers to match.
+func Match(err error, matchers ...Matcher) bool {
+	if err == nil {
+		return false
+	}
+	for _, m := range matchers {
+		if m(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchStatus returns a matcher that matches errors that have any
+// of the status codes (see HasStatusCode).
+func MatchStatus(statusCodes ...int) Matcher {
+	return func(err error) bool {
+		return HasStatusCode(err, statusCodes...)
+	}
+}
+
+// MatchStatusIn returns a matcher that matches errors that have a status
+// code in the range lo to hi, inclusive (see HasStatusCodeIn).
+func MatchStatusIn(lo, hi int) Matcher {
+	return func(err error) bool {
+		return HasStatusCodeIn(err, lo, hi)
+	}
+}
+
+// MatchCode returns a matcher that matches errors that have any
+// of the codes (see HasCode).
+func MatchCode(codes ...string) Matcher {
+	return func(err error) bool {
+		return HasCode(err, codes...)
+	}
+}
+
+// MatchCodePrefix returns a matcher that matches errors that have a
+// hierarchical code in the family of codes identified by any of the
+// prefixes (see HasCodePrefix).
+func MatchCodePrefix(prefixes ...string) Matcher {
+	return func(err error) bool {
+		return HasCodePrefix(err, prefixes...)
+	}
+}
+
+// MatchTemporary returns a matcher that matches temporary errors
+// (see IsTemporary).
+func MatchTemporary() Matcher {
+	return IsTemporary
+}
+
+// MatchError returns a matcher that matches errors that are equal to target,
+// or that have a cause that is equal to target. It is useful for matching
+// sentinel errors such as io.EOF.
+func MatchError(target error) Matcher {
+	canCompare := target != nil && reflect.TypeOf(target).Comparable()
+	return func(err error) bool {
+		if !canCompare {
+			return false
+		}
+		return walk(err, func(err error) bool {
+			return reflect.TypeOf(err).Comparable() && err == target
+		})
+	}
+}
+
+// And returns a matcher that matches errors that match all of the matchers.
+func And(matchers ...Matcher) Matcher {
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+		for _, m := range matchers {
+			if !m(err) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a matcher that matches errors that match any of the matchers.
+func Or(matchers ...Matcher) Matcher {
+	return func(err error) bool {
+		return Match(err, matchers...)
+	}
+}
+
+// Not returns a matcher that matches errors that do not match m.
+// Like all matchers, it does not match a nil error.
+func Not(m Matcher) Matcher {
+	return func(err error) bool {
+		return err != nil && !m(err)
+	}
+}
+
+// Switcher calls a function for the first case that matches an error.
+// Use Switch to create a Switcher.
+type Switcher struct {
+	err  error
+	done bool
+}
+
+// Switch returns a Switcher for dispatching on the kind of err. The functions
+// for the first matching case, or the default if no case matches, are called
+// with err.
+//  errkind.Switch(err).
+//      Case(errkind.MatchStatus(404), func(err error) {
+//          // not found
+//      }).
+//      Case(errkind.MatchTemporary(), func(err error) {
+//          // retry
+//      }).
+//      Default(func(err error) {
+//          // anything else
+//      })
+//
+// If err is nil, no functions are called.
+func Switch(err error) *Switcher {
+	return &Switcher{
+		err:  err,
+		done: err == nil,
+	}
+}
+
+// Case calls fn with the error if the error matches m, and no
+// previous case has matched.
+func (s *Switcher) Case(m Matcher, fn func(err error)) *Switcher {
+	if !s.done && m(s.err) {
+		s.done = true
+		fn(s.err)
+	}
+	return s
+}
+
+// Default calls fn with the error if no previous case has matched.
+func (s *Switcher) Default(fn func(err error)) {
+	if !s.done {
+		s.done = true
+		fn(s.err)
+	}
+}
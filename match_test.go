@@ -0,0 +1,89 @@
+package errkind
+
+import (
+	"io"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestMatch(t *testing.T) {
+	notFound := errors.Wrap(NotFound(), "cannot get widget")
+	noSuchKey := errors.Wrap(PublicWithCode("no such key", 404, "NoSuchKey"), "cannot get object")
+	timeout := errors.Wrap(Temporary("timeout"), "cannot connect")
+	eof := errors.Wrap(io.EOF, "cannot read")
+	tests := []struct {
+		err      error
+		matchers []Matcher
+		want     bool
+	}{
+		{err: nil, matchers: []Matcher{Not(MatchStatus(404))}, want: false},
+		{err: notFound, matchers: nil, want: false},
+		{err: notFound, matchers: []Matcher{MatchStatus(404)}, want: true},
+		{err: notFound, matchers: []Matcher{MatchStatus(400, 404)}, want: true},
+		{err: notFound, matchers: []Matcher{MatchStatusIn(400, 499)}, want: true},
+		{err: notFound, matchers: []Matcher{MatchCode("NoSuchKey")}, want: false},
+		{err: notFound, matchers: []Matcher{MatchStatus(500), MatchCode("NoSuchKey")}, want: false},
+		{err: noSuchKey, matchers: []Matcher{MatchStatus(500), MatchCode("NoSuchKey")}, want: true},
+		{err: noSuchKey, matchers: []Matcher{And(MatchStatus(404), MatchCode("NoSuchKey"))}, want: true},
+		{err: notFound, matchers: []Matcher{And(MatchStatus(404), MatchCode("NoSuchKey"))}, want: false},
+		{err: notFound, matchers: []Matcher{And(MatchStatus(404), Not(MatchCode("NoSuchKey")))}, want: true},
+		{err: noSuchKey, matchers: []Matcher{Or(MatchCodePrefix("No"), MatchCodePrefix("NoSuchKey"))}, want: true},
+		{err: timeout, matchers: []Matcher{MatchTemporary()}, want: true},
+		{err: timeout, matchers: []Matcher{Not(MatchTemporary())}, want: false},
+		{err: eof, matchers: []Matcher{MatchError(io.EOF)}, want: true},
+		{err: eof, matchers: []Matcher{MatchError(io.ErrUnexpectedEOF)}, want: false},
+		{err: eof, matchers: []Matcher{MatchError(nil)}, want: false},
+		{err: eof, matchers: []Matcher{MatchError(testingSliceError{"x"})}, want: false},
+		{err: testingSliceError{"x"}, matchers: []Matcher{MatchError(io.EOF)}, want: false},
+	}
+	for i, tt := range tests {
+		if got, want := Match(tt.err, tt.matchers...), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: ""},
+		{err: NotFound(), want: "not found"},
+		{err: PublicWithCode("no such key", 404, "NoSuchKey"), want: "no such key"},
+		{err: errors.Wrap(Temporary("timeout"), "cannot connect"), want: "temporary"},
+		{err: BadRequest(), want: "default"},
+	}
+	for i, tt := range tests {
+		var got string
+		var gotErr error
+		set := func(s string) func(error) {
+			return func(err error) {
+				if got != "" {
+					t.Errorf("%d: called twice: %s, %s", i, got, s)
+				}
+				got = s
+				gotErr = err
+			}
+		}
+		Switch(tt.err).
+			Case(MatchCode("NoSuchKey"), set("no such key")).
+			Case(MatchStatus(404), set("not found")).
+			Case(MatchTemporary(), set("temporary")).
+			Case(MatchStatus(404), set("not found again")).
+			Default(set("default"))
+		if want := tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if gotErr != tt.err {
+			t.Errorf("%d: want=%v, got=%v", i, tt.err, gotErr)
+		}
+	}
+}
+
+type testingSliceError []string
+
+func (err testingSliceError) Error() string {
+	return "testing slice error"
+}
@@ -0,0 +1,81 @@
+package errkind
+
+import "reflect"
+
+// StatusCodeMethods contains the names of methods that StatusCode
+// uses to obtain the status code of an error, in order of preference.
+// A method is used if it has no arguments and returns a single integer value,
+// which can be a named type. This allows errors from libraries that do not
+// follow the statusCoder convention to be used without writing adapters.
+//  errkind.StatusCodeMethods = append(errkind.StatusCodeMethods, "HTTPStatus")
+//
+// The StatusCode() int method of the statusCoder interface is
+// always recognised, even if it is removed from StatusCodeMethods.
+//
+// StatusCodeMethods should only be modified during program initialization.
+var StatusCodeMethods = []string{"StatusCode", "HTTPStatusCode", "Status"}
+
+// CodeMethods contains the names of methods that Code and HasCode
+// use to obtain the code of an error, in order of preference. A method
+// is used if it has no arguments and returns a single string value, which
+// can be a named type (such as the SQLSTATE type of a database driver).
+//  errkind.CodeMethods = append(errkind.CodeMethods, "SQLState")
+//
+// The Code() string method of the coder interface is
+// always recognised, even if it is removed from CodeMethods.
+//
+// CodeMethods should only be modified during program initialization.
+var CodeMethods = []string{"Code", "ErrorCode"}
+
+// statusCodeOf returns the status code of err, which is not a cause of err.
+func statusCodeOf(err error) (int, bool) {
+	if sc, ok := err.(statusCoder); ok {
+		return sc.StatusCode(), true
+	}
+	v, ok := callMethod(err, StatusCodeMethods, func(k reflect.Kind) bool {
+		return k >= reflect.Int && k <= reflect.Uint64
+	})
+	if !ok {
+		return 0, false
+	}
+	if v.Kind() >= reflect.Uint {
+		return int(v.Uint()), true
+	}
+	return int(v.Int()), true
+}
+
+// codeOf returns the code of err, which is not a cause of err.
+func codeOf(err error) (string, bool) {
+	if c, ok := err.(coder); ok {
+		return c.Code(), true
+	}
+	v, ok := callMethod(err, CodeMethods, func(k reflect.Kind) bool {
+		return k == reflect.String
+	})
+	if !ok {
+		return "", false
+	}
+	return v.String(), true
+}
+
+// callMethod calls the first method of err with one of the names that has
+// no arguments and a single result of a kind accepted by kindOK, and returns
+// the result.
+func callMethod(err error, names []string, kindOK func(reflect.Kind) bool) (reflect.Value, bool) {
+	if err == nil {
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(err)
+	for _, name := range names {
+		m := v.MethodByName(name)
+		if !m.IsValid() {
+			continue
+		}
+		mt := m.Type()
+		if mt.NumIn() != 0 || mt.NumOut() != 1 || !kindOK(mt.Out(0).Kind()) {
+			continue
+		}
+		return m.Call(nil)[0], true
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,126 @@
+package errkind
+
+import (
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+type testingSQLState string
+
+type testingHTTPStatus int
+
+type testingHTTPStatusCodeError struct{}
+
+func (testingHTTPStatusCodeError) Error() string       { return "http status code" }
+func (testingHTTPStatusCodeError) HTTPStatusCode() int { return 502 }
+
+type testingStatusMethodError struct{}
+
+func (testingStatusMethodError) Error() string { return "status" }
+func (testingStatusMethodError) Status() int   { return 429 }
+
+type testingStatusStringError struct{}
+
+func (testingStatusStringError) Error() string  { return "status string" }
+func (testingStatusStringError) Status() string { return "broken" }
+
+type testingNamedStatusError struct{}
+
+func (testingNamedStatusError) Error() string                 { return "named status" }
+func (testingNamedStatusError) StatusCode() testingHTTPStatus { return 409 }
+
+type testingUintStatusError struct{}
+
+func (testingUintStatusError) Error() string      { return "uint status" }
+func (testingUintStatusError) StatusCode() uint32 { return 404 }
+
+type testingErrorCodeError struct{}
+
+func (testingErrorCodeError) Error() string     { return "error code" }
+func (testingErrorCodeError) ErrorCode() string { return "Throttled" }
+
+type testingSQLStateError struct{}
+
+func (testingSQLStateError) Error() string         { return "sql state" }
+func (testingSQLStateError) Code() testingSQLState { return "23505" }
+
+type testingIntCodeError struct{}
+
+func (testingIntCodeError) Error() string { return "int code" }
+func (testingIntCodeError) Code() int     { return 42 }
+
+type testingArgCodeError struct{}
+
+func (testingArgCodeError) Error() string              { return "arg code" }
+func (testingArgCodeError) Code(lang string) string    { return "Localized" }
+func (testingArgCodeError) ErrorCode() (string, error) { return "TwoResults", nil }
+
+type testingCustomError struct{}
+
+func (testingCustomError) Error() string      { return "custom" }
+func (testingCustomError) SQLState() string   { return "40001" }
+func (testingCustomError) HTTPStatus() uint16 { return 503 }
+
+func TestAlternativeMethods(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{err: testingHTTPStatusCodeError{}, status: 502},
+		{err: errors.Wrap(testingHTTPStatusCodeError{}, "wrapped"), status: 502},
+		{err: testingStatusMethodError{}, status: 429},
+		{err: testingStatusStringError{}, status: 0},
+		{err: testingNamedStatusError{}, status: 409},
+		{err: testingUintStatusError{}, status: 404},
+		{err: testingErrorCodeError{}, code: "Throttled"},
+		{err: testingSQLStateError{}, code: "23505"},
+		{err: errors.Wrap(testingSQLStateError{}, "wrapped"), code: "23505"},
+		{err: testingIntCodeError{}, code: ""},
+		{err: testingArgCodeError{}, code: ""},
+		{err: testingCustomError{}, status: 0, code: ""},
+	}
+	for i, tt := range tests {
+		if got, want := StatusCode(tt.err), tt.status; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := Code(tt.err), tt.code; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if tt.code != "" {
+			if got, want := HasCode(tt.err, tt.code), true; got != want {
+				t.Errorf("%d: want=%v, got=%v", i, want, got)
+			}
+		}
+	}
+}
+
+func TestConfigureMethods(t *testing.T) {
+	defer func(statusCodeMethods, codeMethods []string) {
+		StatusCodeMethods, CodeMethods = statusCodeMethods, codeMethods
+	}(StatusCodeMethods, CodeMethods)
+
+	StatusCodeMethods = append([]string{"HTTPStatus"}, StatusCodeMethods...)
+	CodeMethods = append([]string{"SQLState"}, CodeMethods...)
+	err := errors.Wrap(testingCustomError{}, "wrapped")
+	if got, want := StatusCode(err), 503; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := Code(err), "40001"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+
+	// interface methods are always recognised
+	StatusCodeMethods = nil
+	CodeMethods = nil
+	if got, want := StatusCode(NotFound()), 404; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := Code(PublicWithCode("locked", 409, "Locked")), "Locked"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := StatusCode(testingHTTPStatusCodeError{}), 0; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
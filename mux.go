@@ -0,0 +1,198 @@
+package errkind
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/jjeffery/errors"
+)
+
+// allowedMethodser is an interface implemented by errors that have
+// a list of the methods supported by the requested resource.
+type allowedMethodser interface {
+	AllowedMethods() []string
+}
+
+// AllowedMethods returns a copy of the allowed methods set when the error
+// was created.
+func (d *details) AllowedMethods() []string {
+	if d == nil {
+		return nil
+	}
+	return append([]string(nil), d.allowedMethods...)
+}
+
+// MethodNotAllowed returns a client error that has a status of
+// 405 (method not allowed), and the methods supported by the requested
+// resource. The allowed methods are available using AllowedMethods, and
+// are included in the Allow header returned by Headers.
+//  return errkind.MethodNotAllowed([]string{"GET", "HEAD"})
+//
+// The returned error has a PublicStatusCode() method, which indicates that the
+// status code is public and can be returned to a client.
+func MethodNotAllowed(allowed []string, msg ...string) errors.Error {
+	return statusError{
+		message: makeMessage("method not allowed", msg),
+		status:  http.StatusMethodNotAllowed,
+		details: newDetailsWith(1, []Option{SetAllow(allowed...)}),
+	}
+}
+
+// AllowedMethods returns the methods supported by the requested resource
+// for err or one of its causes (see MethodNotAllowed and SetAllow),
+// or nil if there are none. If err was created by WithPublic, the allowed
+// methods of the masked error are not returned.
+//
+// An error has allowed methods if it, or one of its causes, implements
+// the following interface and returns at least one method.
+//  type allowedMethodser interface {
+//      AllowedMethods() []string
+//  }
+func AllowedMethods(err error) []string {
+	var allowed []string
+	walkPublic(err, func(err error) bool {
+		if am, ok := err.(allowedMethodser); ok {
+			allowed = am.AllowedMethods()
+		}
+		return len(allowed) > 0
+	})
+	return allowed
+}
+
+// WrapMux returns a handler that calls mux, and converts the responses that
+// mux writes when no route matches the request into errors, which are passed
+// to onError to be written to the client. This ensures that errors reported by
+// a router are written in the same way as errors reported by handlers.
+//  http.ListenAndServe(addr, errkind.WrapMux(mux, writeError))
+//
+// A response with status 404 (not found) is converted into an error created
+// by NotFound, and a response with status 405 (method not allowed) is converted
+// into an error created by MethodNotAllowed, with the methods in the Allow
+// header of the response. The errors have the request method and path attached
+// as key/value pairs.
+//
+// If mux is an http.ServeMux, or any other router with a Handler method that
+// reports the pattern matching a request, only the responses written when no
+// pattern matches are converted. Responses written by the handlers of matching
+// routes are never converted. For other routers, WrapMux cannot tell a router's
+// response from a handler's response, so any 404 or 405 response with a plain
+// text content type, such as the responses written by http.Error, is converted.
+func WrapMux(mux http.Handler, onError func(w http.ResponseWriter, r *http.Request, err error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallback := false
+		if hf, ok := mux.(handlerFinder); ok {
+			if _, pattern := hf.Handler(r); pattern != "" {
+				// the response is written by the handler for the route
+				mux.ServeHTTP(w, r)
+				return
+			}
+			fallback = true
+		}
+		mux.ServeHTTP(&muxResponseWriter{
+			ResponseWriter: w,
+			request:        r,
+			onError:        onError,
+			fallback:       fallback,
+		}, r)
+	})
+}
+
+// handlerFinder is an interface implemented by routers that report the
+// handler and pattern for a request, such as http.ServeMux. The pattern
+// is blank if no route matches the request.
+type handlerFinder interface {
+	Handler(r *http.Request) (h http.Handler, pattern string)
+}
+
+// muxResponseWriter intercepts responses written by a mux
+// when no route matches the request.
+type muxResponseWriter struct {
+	http.ResponseWriter
+	request     *http.Request
+	onError     func(w http.ResponseWriter, r *http.Request, err error)
+	fallback    bool // no route matches the request
+	wroteHeader bool
+	intercepted bool
+}
+
+func (w *muxResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if err := w.fallbackError(status); err != nil {
+		w.intercepted = true
+		header := w.Header()
+		header.Del("Allow")
+		header.Del("Content-Type")
+		header.Del("X-Content-Type-Options")
+		w.onError(w.ResponseWriter, w.request, err.With(
+			"method", w.request.Method,
+			"path", w.request.URL.Path,
+		))
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *muxResponseWriter) Write(b []byte) (int, error) {
+	// an implicit status 200 (ok) is never intercepted
+	w.wroteHeader = true
+	if w.intercepted {
+		// discard the body written by the mux
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements the http.Flusher interface, so that handlers can
+// stream responses. It does nothing if the underlying response writer
+// does not implement http.Flusher.
+func (w *muxResponseWriter) Flush() {
+	// an implicit status 200 (ok) is never intercepted
+	w.wroteHeader = true
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && !w.intercepted {
+		f.Flush()
+	}
+}
+
+// Hijack implements the http.Hijacker interface, so that handlers can
+// take over the connection, eg for websockets. It returns http.ErrNotSupported
+// if the underlying response writer does not implement http.Hijacker.
+func (w *muxResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
+
+// Unwrap returns the underlying response writer, for use
+// by http.ResponseController.
+func (w *muxResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// fallbackError returns the error for a response written with status,
+// or nil if the response should not be converted into an error.
+func (w *muxResponseWriter) fallbackError(status int) errors.Error {
+	if !w.fallback && !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain") {
+		return nil
+	}
+	switch status {
+	case http.StatusNotFound:
+		return NotFound()
+	case http.StatusMethodNotAllowed:
+		var allowed []string
+		for _, value := range w.Header()["Allow"] {
+			for _, method := range strings.Split(value, ",") {
+				if method = strings.TrimSpace(method); method != "" {
+					allowed = append(allowed, method)
+				}
+			}
+		}
+		return MethodNotAllowed(allowed)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+//go:build go1.22
+// +build go1.22
+
+package errkind
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWrapServeMuxMethods(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /widgets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "widget ", r.PathValue("id"))
+	})
+	writeError := func(w http.ResponseWriter, r *http.Request, err error) {
+		w.Header().Set("Allow", Headers(err).Get("Allow"))
+		w.WriteHeader(StatusCode(err))
+		fmt.Fprint(w, "errkind: ", err)
+	}
+	handler := WrapMux(mux, writeError)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("DELETE", "/widgets/42", nil))
+	if got, want := w.Code, 405; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := w.Header().Get("Allow"), "GET, HEAD"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := w.Body.String(), "errkind: method not allowed method=DELETE path=/widgets/42"; got != want {
+		t.Errorf("want=%q, got=%q", want, got)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/widgets/42", nil))
+	if got, want := w.Body.String(), "widget 42"; got != want {
+		t.Errorf("want=%q, got=%q", want, got)
+	}
+}
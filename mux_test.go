@@ -0,0 +1,263 @@
+package errkind
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestMethodNotAllowed(t *testing.T) {
+	tests := []struct {
+		err     error
+		errText string
+		allowed []string
+		allow   string
+	}{
+		{
+			err:     MethodNotAllowed([]string{"GET", "HEAD"}),
+			errText: "method not allowed",
+			allowed: []string{"GET", "HEAD"},
+			allow:   "GET, HEAD",
+		},
+		{
+			err:     errors.Wrap(MethodNotAllowed([]string{"POST"}, "widgets are read-only"), "wrapped"),
+			errText: "wrapped: widgets are read-only",
+			allowed: []string{"POST"},
+			allow:   "POST",
+		},
+		{
+			err:     MethodNotAllowed(nil),
+			errText: "method not allowed",
+			allowed: nil,
+			allow:   "",
+		},
+		{
+			err:     WithOptions(errors.New("read only"), SetAllow("GET")),
+			errText: "read only",
+			allowed: []string{"GET"},
+			allow:   "GET",
+		},
+		{
+			err:     NotFound(),
+			errText: "not found",
+			allowed: nil,
+			allow:   "",
+		},
+		{
+			// allowed methods of the masked error are not returned
+			err:     WithPublic(MethodNotAllowed([]string{"GET"}), NotFound()),
+			errText: "method not allowed",
+			allowed: nil,
+			allow:   "",
+		},
+	}
+	for i, tt := range tests {
+		if got, want := tt.err.Error(), tt.errText; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := AllowedMethods(tt.err), tt.allowed; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := Headers(tt.err).Get("Allow"), tt.allow; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+	if got, want := StatusCode(MethodNotAllowed(nil)), 405; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := HasPublicStatusCode(MethodNotAllowed(nil)), true; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
+
+func TestAllowedMethodsCopy(t *testing.T) {
+	allowed := []string{"GET", "HEAD"}
+	err := MethodNotAllowed(allowed)
+	allowed[0] = "DELETE"
+	AllowedMethods(err)[1] = "PUT"
+	if got, want := AllowedMethods(err), []string{"GET", "HEAD"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := Headers(err).Get("Allow"), "GET, HEAD"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
+
+func TestWrapMux(t *testing.T) {
+	mux := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/widgets":
+			if r.Method != "GET" {
+				w.Header().Set("Allow", "GET, HEAD")
+				http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			fmt.Fprint(w, "widgets")
+		case "/bare":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case "/bare-json":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"no such widget"}`)
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"no such widget"}`)
+		case "/teapot":
+			http.Error(w, "I'm a teapot", http.StatusTeapot)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	writeError := func(w http.ResponseWriter, r *http.Request, err error) {
+		for key, values := range Headers(err) {
+			w.Header()[key] = values
+		}
+		w.Header().Set("Content-Type", "application/json")
+		view := PublicView(err)
+		w.WriteHeader(view.Status)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"message": view.Message,
+			"path":    KeyValMap(err, FirstValue)["path"],
+		})
+	}
+	handler := WrapMux(mux, writeError)
+
+	tests := []struct {
+		method      string
+		path        string
+		status      int
+		contentType string
+		allow       string
+		body        string
+	}{
+		{
+			method:      "GET",
+			path:        "/widgets",
+			status:      200,
+			contentType: "text/plain; charset=utf-8",
+			body:        "widgets",
+		},
+		{
+			method:      "GET",
+			path:        "/missing",
+			status:      404,
+			contentType: "application/json",
+			body:        `{"message":"not found","path":"/missing"}` + "\n",
+		},
+		{
+			method:      "POST",
+			path:        "/widgets",
+			status:      405,
+			contentType: "application/json",
+			allow:       "GET, HEAD",
+			body:        `{"message":"method not allowed","path":"/widgets"}` + "\n",
+		},
+		{
+			// not plain text, so not converted
+			method: "POST",
+			path:   "/bare",
+			status: 405,
+		},
+		{
+			method: "GET",
+			path:   "/bare-json",
+			status: 404,
+			body:   `{"message":"no such widget"}`,
+		},
+		{
+			method:      "GET",
+			path:        "/json",
+			status:      404,
+			contentType: "application/json",
+			body:        `{"message":"no such widget"}`,
+		},
+		{
+			method:      "GET",
+			path:        "/teapot",
+			status:      418,
+			contentType: "text/plain; charset=utf-8",
+			body:        "I'm a teapot\n",
+		},
+	}
+	for i, tt := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(tt.method, tt.path, nil)
+		handler.ServeHTTP(w, r)
+		if got, want := w.Code, tt.status; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := w.Header().Get("Content-Type"), tt.contentType; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := w.Header().Get("Allow"), tt.allow; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := w.Body.String(), tt.body; got != want {
+			t.Errorf("%d: want=%q, got=%q", i, want, got)
+		}
+	}
+}
+
+func TestWrapServeMux(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/widgets/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "widget 42 does not exist", http.StatusNotFound)
+	})
+	writeError := func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(StatusCode(err))
+		fmt.Fprint(w, "errkind: ", err)
+	}
+	handler := WrapMux(mux, writeError)
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{
+			// written by the handler for the route, so not converted
+			path:   "/widgets/42",
+			status: 404,
+			body:   "widget 42 does not exist\n",
+		},
+		{
+			path:   "/missing",
+			status: 404,
+			body:   "errkind: not found method=GET path=/missing",
+		},
+	}
+	for i, tt := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", tt.path, nil)
+		handler.ServeHTTP(w, r)
+		if got, want := w.Code, tt.status; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := w.Body.String(), tt.body; got != want {
+			t.Errorf("%d: want=%q, got=%q", i, want, got)
+		}
+	}
+}
+
+func TestWrapMuxFlushHijack(t *testing.T) {
+	mux := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "event")
+		w.(http.Flusher).Flush()
+		if _, _, err := w.(http.Hijacker).Hijack(); err != http.ErrNotSupported {
+			t.Errorf("want=%v, got=%v", http.ErrNotSupported, err)
+		}
+	})
+	w := httptest.NewRecorder()
+	WrapMux(mux, nil).ServeHTTP(w, httptest.NewRequest("GET", "/events", nil))
+	if !w.Flushed {
+		t.Error("want flushed")
+	}
+	if got, want := w.Body.String(), "event"; got != want {
+		t.Errorf("want=%q, got=%q", want, got)
+	}
+}
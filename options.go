@@ -0,0 +1,94 @@
+package errkind
+
+import (
+	"fmt"
+
+	"github.com/jjeffery/errors"
+)
+
+// Option sets an optional detail of an error, such as its severity.
+// Use WithOptions to create an error with options.
+type Option func(*details)
+
+// WithOptions returns err with the options set.
+//  return errkind.WithOptions(errkind.NotFound(), errkind.SetSeverity(errkind.SeverityWarning))
+//
+// If err was created by this package, the returned error is a copy of err
+// with the options set, and is otherwise identical to err. Other errors
+// are wrapped with an error that has the options set, and whose cause is err.
+//
+// If err is nil, WithOptions returns nil.
+func WithOptions(err error, opts ...Option) errors.Error {
+	if err == nil {
+		return nil
+	}
+	apply := func(d *details) {
+		for _, opt := range opts {
+			opt(d)
+		}
+	}
+	if d, ok := err.(detailer); ok {
+		return d.withDetails(apply)
+	}
+	d := &details{}
+	apply(d)
+	return detailsError{
+		err:     err,
+		details: d,
+	}
+}
+
+// newDetailsWith returns the details for a new error created with options.
+// The argument skip is the number of stack frames to skip before capturing
+// a stack trace, with 0 identifying the caller of newDetailsWith.
+func newDetailsWith(skip int, opts []Option) *details {
+	d := newDetails(skip + 1)
+	if len(opts) == 0 {
+		return d
+	}
+	if d == nil {
+		d = &details{}
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// detailsError wraps an error that was not created by this package,
+// so that it has details. It implements error, causer and the
+// interfaces implemented by details.
+type detailsError struct {
+	err error
+	*details
+}
+
+func (d detailsError) Error() string {
+	return d.err.Error()
+}
+
+func (d detailsError) Cause() error {
+	return d.err
+}
+
+func (d detailsError) Unwrap() error {
+	return d.err
+}
+
+func (d detailsError) Format(f fmt.State, c rune) {
+	format(f, c, d)
+}
+
+func (d detailsError) MarshalJSON() ([]byte, error) {
+	return Encode(d)
+}
+
+func (d detailsError) With(keyvals ...interface{}) errors.Error {
+	return with(d, keyvals)
+}
+
+func (d detailsError) withDetails(fn func(*details)) errors.Error {
+	d.details = d.details.clone()
+	fn(d.details)
+	return d
+}
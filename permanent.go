@@ -0,0 +1,98 @@
+package errkind
+
+import (
+	"fmt"
+
+	"github.com/jjeffery/errors"
+)
+
+// permanenter is an interface implemented by errors that indicate
+// an error condition that will not succeed if retried.
+type permanenter interface {
+	Permanent() bool
+}
+
+// permanentError implements error, causer, temporaryer and permanenter interfaces.
+type permanentError struct {
+	err error
+	*details
+}
+
+func (p permanentError) Error() string {
+	return p.err.Error()
+}
+
+func (p permanentError) Cause() error {
+	return p.err
+}
+
+func (p permanentError) Unwrap() error {
+	return p.err
+}
+
+func (p permanentError) Temporary() bool {
+	return false
+}
+
+func (p permanentError) Permanent() bool {
+	return true
+}
+
+func (p permanentError) Format(f fmt.State, c rune) {
+	format(f, c, p)
+}
+
+func (p permanentError) MarshalJSON() ([]byte, error) {
+	return Encode(p)
+}
+
+func (p permanentError) With(keyvals ...interface{}) errors.Error {
+	return with(p, keyvals)
+}
+
+func (p permanentError) withDetails(fn func(*details)) errors.Error {
+	p.details = p.details.clone()
+	fn(p.details)
+	return p
+}
+
+// Permanent returns an error that indicates that the operation that caused
+// err will not succeed if retried, even if err or one of its causes reports
+// that it is temporary. This is useful when retrying is pointless or unsafe,
+// for example after a non-idempotent write has partially succeeded.
+//  if err := writeAll(w, data); err != nil {
+//      return errkind.Permanent(err)
+//  }
+//
+// IsTemporary returns false for the returned error, and IsPermanent returns
+// true. The message of the returned error is the message of err, and the
+// returned error's Cause method returns err, so StatusCode, Code and the
+// other functions in this package report on err. If the returned error is
+// itself wrapped in an error that reports that it is temporary, IsTemporary
+// reports on the outer error.
+//
+// If err is nil, Permanent returns nil.
+func Permanent(err error) errors.Error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{
+		err:     err,
+		details: newDetails(1),
+	}
+}
+
+// IsPermanent returns true for errors that have been marked as permanent
+// using Permanent, which indicates that they will not succeed if retried.
+//
+// An error is permanent if it, or one of its causes, implements
+// the following interface and its Permanent method returns true.
+//  type permanenter interface {
+//      Permanent() bool
+//  }
+func IsPermanent(err error) bool {
+	return walk(err, func(err error) bool {
+		p, ok := err.(permanenter)
+		return ok && p.Permanent()
+	})
+}
@@ -0,0 +1,84 @@
+package errkind
+
+import (
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+// testingNetError is a temporary error, like those returned by the net package.
+type testingNetError struct{}
+
+func (testingNetError) Error() string   { return "connection reset" }
+func (testingNetError) Temporary() bool { return true }
+
+func TestPermanent(t *testing.T) {
+	tests := []struct {
+		err       error
+		errText   string
+		status    int
+		code      string
+		temporary bool
+		permanent bool
+	}{
+		{
+			err:       Permanent(nil),
+			errText:   "",
+			temporary: false,
+			permanent: false,
+		},
+		{
+			err:       testingNetError{},
+			errText:   "connection reset",
+			temporary: true,
+			permanent: false,
+		},
+		{
+			err:       Permanent(testingNetError{}),
+			errText:   "connection reset",
+			temporary: false,
+			permanent: true,
+		},
+		{
+			err:       errors.Wrap(Permanent(errors.Wrap(testingNetError{}, "cannot write")), "cannot save"),
+			errText:   "cannot save: cannot write: connection reset",
+			temporary: false,
+			permanent: true,
+		},
+		{
+			err:       Permanent(PublicWithCode("try later", 503, "Busy")),
+			errText:   "try later code=Busy",
+			status:    503,
+			code:      "Busy",
+			temporary: false,
+			permanent: true,
+		},
+		{
+			err:       Permanent(testingNetError{}).With("id", 1),
+			errText:   "connection reset id=1",
+			temporary: false,
+			permanent: true,
+		},
+	}
+	for i, tt := range tests {
+		if tt.err == nil {
+			if tt.errText != "" {
+				t.Errorf("%d: want non-nil", i)
+			}
+		} else if got, want := tt.err.Error(), tt.errText; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := StatusCode(tt.err), tt.status; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := Code(tt.err), tt.code; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := IsTemporary(tt.err), tt.temporary; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := IsPermanent(tt.err), tt.permanent; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
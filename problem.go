@@ -0,0 +1,52 @@
+package errkind
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// ProblemMediaType is the media type for problem details (RFC 7807).
+const ProblemMediaType = "application/problem+json"
+
+// Problem contains the details of an error that can be returned to a
+// requesting client, in the problem details format (RFC 7807), extended
+// with the code and incident ID. This is the error body described by the
+// OpenAPI document generated by errkind-gen.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Incident string `json:"incident,omitempty"`
+}
+
+// Problem returns the view in the problem details format. The title is the
+// text for the status code (eg "Not Found" for status 404), and the detail is
+// the message. The type is blank, which is equivalent to "about:blank".
+//  w.Header().Set("Content-Type", errkind.ProblemMediaType)
+//  w.WriteHeader(view.Status)
+//  json.NewEncoder(w).Encode(view.Problem())
+func (v View) Problem() Problem {
+	title := http.StatusText(v.Status)
+	if title == "" {
+		title = "Status " + strconv.Itoa(v.Status)
+	}
+	return Problem{
+		Title:    title,
+		Status:   v.Status,
+		Detail:   v.Message,
+		Code:     v.Code,
+		Incident: v.Incident,
+	}
+}
+
+// PublicProblem returns the public view of err in the problem details format.
+// It is the same as calling PublicView(err).Problem(), except that if err is nil
+// the zero problem is returned.
+func PublicProblem(err error) Problem {
+	if err == nil {
+		return Problem{}
+	}
+	return PublicView(err).Problem()
+}
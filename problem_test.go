@@ -0,0 +1,47 @@
+package errkind
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestPublicProblem(t *testing.T) {
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	tests := []struct {
+		err      error
+		wantJSON string
+	}{
+		{
+			err:      nil,
+			wantJSON: `{"title":"","status":0}`,
+		},
+		{
+			err:      NotFound(),
+			wantJSON: `{"title":"Not Found","status":404,"detail":"not found"}`,
+		},
+		{
+			err:      PublicWithCode("widget is locked", 409, "Locked"),
+			wantJSON: `{"title":"Conflict","status":409,"detail":"widget is locked","code":"Locked"}`,
+		},
+		{
+			err:      WithIncident(ctx, errors.New("cannot connect")),
+			wantJSON: `{"title":"Internal Server Error","status":500,"detail":"internal server error","incident":"req-1"}`,
+		},
+		{
+			err:      Public("client closed request", 499),
+			wantJSON: `{"title":"Status 499","status":499,"detail":"client closed request"}`,
+		},
+	}
+	for i, tt := range tests {
+		data, err := json.Marshal(PublicProblem(tt.err))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(data), tt.wantJSON; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
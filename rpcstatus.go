@@ -0,0 +1,420 @@
+package errkind
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jjeffery/errors"
+)
+
+// RPCDomain is the domain reported in the google.rpc.ErrorInfo detail of
+// statuses created by EncodeRPCStatus. When decoding, statuses with this
+// domain are trusted to have been created by EncodeRPCStatus, so their
+// message, status code and code are public.
+//
+// RPCDomain should only be modified during program initialization.
+var RPCDomain = "errkind"
+
+// errorInfoTypeURL is the type URL of the google.rpc.ErrorInfo message.
+const errorInfoTypeURL = "type.googleapis.com/google.rpc.ErrorInfo"
+
+// gRPC status codes, see google/rpc/code.proto.
+const (
+	rpcOK                 = 0
+	rpcCanceled           = 1
+	rpcUnknown            = 2
+	rpcInvalidArgument    = 3
+	rpcDeadlineExceeded   = 4
+	rpcNotFound           = 5
+	rpcAlreadyExists      = 6
+	rpcPermissionDenied   = 7
+	rpcResourceExhausted  = 8
+	rpcFailedPrecondition = 9
+	rpcAborted            = 10
+	rpcOutOfRange         = 11
+	rpcUnimplemented      = 12
+	rpcInternal           = 13
+	rpcUnavailable        = 14
+	rpcDataLoss           = 15
+	rpcUnauthenticated    = 16
+)
+
+// rpcCodes maps HTTP status codes to gRPC status codes. Other status
+// codes are mapped by class (see statusRPCCode).
+var rpcCodes = map[int]int{
+	http.StatusBadRequest:                   rpcInvalidArgument,
+	http.StatusUnauthorized:                 rpcUnauthenticated,
+	http.StatusForbidden:                    rpcPermissionDenied,
+	http.StatusNotFound:                     rpcNotFound,
+	http.StatusConflict:                     rpcAborted,
+	http.StatusPreconditionFailed:           rpcFailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: rpcOutOfRange,
+	http.StatusUnprocessableEntity:          rpcInvalidArgument,
+	http.StatusTooManyRequests:              rpcResourceExhausted,
+	499:                                     rpcCanceled, // client closed request
+	http.StatusInternalServerError:          rpcInternal,
+	http.StatusNotImplemented:               rpcUnimplemented,
+	http.StatusServiceUnavailable:           rpcUnavailable,
+	http.StatusGatewayTimeout:               rpcDeadlineExceeded,
+}
+
+// statusRPCCode returns the gRPC status code for the HTTP status code. Client
+// errors not in rpcCodes are FAILED_PRECONDITION, and server errors not in
+// rpcCodes are INTERNAL.
+func statusRPCCode(status int) int {
+	if code, ok := rpcCodes[status]; ok {
+		return code
+	}
+	switch {
+	case status >= 400 && status < 500:
+		return rpcFailedPrecondition
+	case status >= 500 && status < 600:
+		return rpcInternal
+	}
+	return rpcUnknown
+}
+
+// rpcReason returns the google.rpc.ErrorInfo reason used for errors
+// without a code, which is derived from the HTTP status code, eg
+// "HTTP_NOT_FOUND" for 404, or "HTTP_499" for a status code that
+// has no standard text.
+func rpcReason(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "HTTP_" + strconv.Itoa(status)
+	}
+	words := strings.FieldsFunc(strings.ToUpper(text), func(r rune) bool {
+		return (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	})
+	return "HTTP_" + strings.Join(words, "_")
+}
+
+// httpStatuses maps gRPC status codes to HTTP status codes.
+var httpStatuses = map[int]int{
+	rpcCanceled:           499, // client closed request
+	rpcUnknown:            http.StatusInternalServerError,
+	rpcInvalidArgument:    http.StatusBadRequest,
+	rpcDeadlineExceeded:   http.StatusGatewayTimeout,
+	rpcNotFound:           http.StatusNotFound,
+	rpcAlreadyExists:      http.StatusConflict,
+	rpcPermissionDenied:   http.StatusForbidden,
+	rpcResourceExhausted:  http.StatusTooManyRequests,
+	rpcFailedPrecondition: http.StatusBadRequest,
+	rpcAborted:            http.StatusConflict,
+	rpcOutOfRange:         http.StatusBadRequest,
+	rpcUnimplemented:      http.StatusNotImplemented,
+	rpcInternal:           http.StatusInternalServerError,
+	rpcUnavailable:        http.StatusServiceUnavailable,
+	rpcDataLoss:           http.StatusInternalServerError,
+	rpcUnauthenticated:    http.StatusUnauthorized,
+}
+
+// Keys for the google.rpc.ErrorInfo metadata.
+const (
+	rpcStatusKey    = "status"
+	rpcTemporaryKey = "temporary"
+	rpcIncidentKey  = "incident"
+)
+
+// EncodeRPCStatus returns the protocol buffers wire encoding of err as a
+// google.rpc.Status message, which is the error model used by gRPC. Because
+// the status is returned to requesting clients, it is based on PublicView(err):
+//  code     the gRPC status code corresponding to the view's status code
+//  message  the view's message
+//  details  a single google.rpc.ErrorInfo with the view's code as the reason,
+//           RPCDomain as the domain, and metadata containing the view's status
+//           code, the incident ID and whether the error is temporary
+//
+// Status codes without a corresponding gRPC status code are mapped by class:
+// client errors are FAILED_PRECONDITION and server errors are INTERNAL. Because
+// google.rpc.ErrorInfo requires a reason, if the view has no code the reason is
+// derived from the status code, eg "HTTP_NOT_FOUND" for 404.
+//
+// Encoding is deterministic: the same error always has the same encoding.
+//
+// If err is nil, EncodeRPCStatus returns the encoding of a status with the
+// OK code, which is an empty message.
+func EncodeRPCStatus(err error) []byte {
+	if err == nil {
+		return []byte{}
+	}
+	view := PublicView(err)
+	reason := view.Code
+	if reason == "" {
+		reason = rpcReason(view.Status)
+	}
+
+	metadata := map[string]string{
+		rpcStatusKey: strconv.Itoa(view.Status),
+	}
+	if IsTemporary(err) {
+		metadata[rpcTemporaryKey] = "true"
+	}
+	if view.Incident != "" {
+		metadata[rpcIncidentKey] = view.Incident
+	}
+
+	var info protoBuffer
+	info.appendString(1, reason)
+	info.appendString(2, RPCDomain)
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var entry protoBuffer
+		entry.appendString(1, key)
+		entry.appendString(2, metadata[key])
+		info.appendBytes(3, entry)
+	}
+
+	var detail protoBuffer
+	detail.appendString(1, errorInfoTypeURL)
+	detail.appendBytes(2, info)
+
+	var status protoBuffer
+	status.appendVarint(1, uint64(statusRPCCode(view.Status)))
+	status.appendString(2, view.Message)
+	status.appendBytes(3, detail)
+	return status
+}
+
+// DecodeRPCStatus rebuilds an error from the protocol buffers wire encoding
+// of a google.rpc.Status message. The status code of the error is taken
+// from the google.rpc.ErrorInfo metadata if present, otherwise it is the HTTP
+// status code corresponding to the gRPC status code. The code of the error is
+// the reason in the google.rpc.ErrorInfo detail, if present, unless the domain is
+// RPCDomain and the reason is derived from the status code (see EncodeRPCStatus).
+//
+// If the status has a google.rpc.ErrorInfo detail whose domain is RPCDomain, the
+// message, status code and code of the error are public, as they were public
+// when encoded by EncodeRPCStatus. Errors decoded from other statuses are not
+// public, because their message is intended for developers, not end users.
+//
+// If data is the encoding of a status with the OK code, DecodeRPCStatus returns
+// nil. If data cannot be decoded, DecodeRPCStatus returns an error describing
+// the problem.
+func DecodeRPCStatus(data []byte) error {
+	var (
+		rpcCode uint64
+		je      jsonError
+		info    *rpcErrorInfo
+	)
+	err := parseProto(data, func(num int, value uint64, b []byte) error {
+		switch num {
+		case 1:
+			rpcCode = value
+		case 2:
+			je.Message = string(b)
+		case 3:
+			typeURL, detail, err := parseAny(b)
+			if err != nil {
+				return err
+			}
+			if typeURL == errorInfoTypeURL && info == nil {
+				if info, err = parseErrorInfo(detail); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot decode google.rpc.Status")
+	}
+	if rpcCode == rpcOK {
+		return nil
+	}
+
+	je.Error = je.Message
+	je.Status = httpStatuses[int(rpcCode)]
+	if je.Status == 0 {
+		je.Status = http.StatusInternalServerError
+	}
+	if info != nil {
+		je.Code = info.reason
+		if status, err := strconv.Atoi(info.metadata[rpcStatusKey]); err == nil && status > 0 {
+			je.Status = status
+		}
+		je.Temporary = info.metadata[rpcTemporaryKey] == "true"
+		je.Incident = info.metadata[rpcIncidentKey]
+		if info.domain == RPCDomain {
+			if je.Code == rpcReason(je.Status) {
+				// no code when encoded
+				je.Code = ""
+			}
+			je.Public = []string{"message", "status"}
+			if je.Code != "" {
+				je.Public = append(je.Public, "code")
+			}
+		}
+	}
+	if je.Code != "" {
+		// same message as the error created by PublicWithCode
+		je.Error = publicStatusCodeError{message: je.Message, code: je.Code}.Error()
+	}
+	return je.decode()
+}
+
+// rpcErrorInfo contains the fields of a google.rpc.ErrorInfo message.
+type rpcErrorInfo struct {
+	reason   string
+	domain   string
+	metadata map[string]string
+}
+
+// parseAny parses a google.protobuf.Any message.
+func parseAny(data []byte) (typeURL string, value []byte, err error) {
+	err = parseProto(data, func(num int, _ uint64, b []byte) error {
+		switch num {
+		case 1:
+			typeURL = string(b)
+		case 2:
+			value = b
+		}
+		return nil
+	})
+	return typeURL, value, err
+}
+
+// parseErrorInfo parses a google.rpc.ErrorInfo message.
+func parseErrorInfo(data []byte) (*rpcErrorInfo, error) {
+	info := &rpcErrorInfo{metadata: make(map[string]string)}
+	err := parseProto(data, func(num int, _ uint64, b []byte) error {
+		switch num {
+		case 1:
+			info.reason = string(b)
+		case 2:
+			info.domain = string(b)
+		case 3:
+			var key, value string
+			err := parseProto(b, func(num int, _ uint64, b []byte) error {
+				switch num {
+				case 1:
+					key = string(b)
+				case 2:
+					value = string(b)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			info.metadata[key] = value
+		}
+		return nil
+	})
+	return info, err
+}
+
+// Protocol buffers wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// protoBuffer is a minimal encoder for the protocol buffers wire format.
+// Fields with zero values are not encoded, as in proto3.
+type protoBuffer []byte
+
+func (p *protoBuffer) appendRawVarint(v uint64) {
+	for v >= 0x80 {
+		*p = append(*p, byte(v)|0x80)
+		v >>= 7
+	}
+	*p = append(*p, byte(v))
+}
+
+func (p *protoBuffer) appendVarint(num int, v uint64) {
+	if v == 0 {
+		return
+	}
+	p.appendRawVarint(uint64(num)<<3 | wireVarint)
+	p.appendRawVarint(v)
+}
+
+func (p *protoBuffer) appendBytes(num int, b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	p.appendRawVarint(uint64(num)<<3 | wireBytes)
+	p.appendRawVarint(uint64(len(b)))
+	*p = append(*p, b...)
+}
+
+func (p *protoBuffer) appendString(num int, s string) {
+	p.appendBytes(num, []byte(s))
+}
+
+// errTruncated is returned when protocol buffers data is truncated.
+var errTruncated = errors.New("unexpected end of data")
+
+// parseProto parses protocol buffers data, calling fn for each varint and
+// length-delimited field. Fixed-width fields are skipped.
+func parseProto(data []byte, fn func(num int, value uint64, b []byte) error) error {
+	for len(data) > 0 {
+		tag, n := readVarint(data)
+		if n == 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		num, wireType := int(tag>>3), int(tag&7)
+		if num <= 0 {
+			return fmt.Errorf("invalid field number %d", num)
+		}
+		switch wireType {
+		case wireVarint:
+			value, n := readVarint(data)
+			if n == 0 {
+				return errTruncated
+			}
+			data = data[n:]
+			if err := fn(num, value, nil); err != nil {
+				return err
+			}
+		case wireBytes:
+			length, n := readVarint(data)
+			if n == 0 || uint64(len(data)-n) < length {
+				return errTruncated
+			}
+			b := data[n : n+int(length)]
+			data = data[n+int(length):]
+			if err := fn(num, 0, b); err != nil {
+				return err
+			}
+		case wireFixed64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			data = data[8:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			data = data[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %d", wireType)
+		}
+	}
+	return nil
+}
+
+// readVarint reads a varint from data, returning the value and the number
+// of bytes read, which is zero if data does not contain a valid varint.
+func readVarint(data []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < len(data) && i < 10; i++ {
+		b := data[i]
+		v |= uint64(b&0x7f) << (7 * uint(i))
+		if b < 0x80 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
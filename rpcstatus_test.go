@@ -0,0 +1,201 @@
+package errkind
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestEncodeRPCStatus(t *testing.T) {
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{
+			err:  nil,
+			want: "",
+		},
+		{
+			err:  NotFound(),
+			want: "080512096e6f7420666f756e641a540a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f12280a0e485454505f4e4f545f464f554e4412076572726b696e641a0d0a067374617475731203343034",
+		},
+		{
+			err:  errors.Wrap(PublicWithCode("widget is locked", 409, "Locked"), "cannot update"),
+			want: "080a1210776964676574206973206c6f636b65641a4c0a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f12200a064c6f636b656412076572726b696e641a0d0a067374617475731203343039",
+		},
+		{
+			err:  errors.New("cannot connect to database"),
+			want: "080d1215696e7465726e616c20736572766572206572726f721a600a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f12340a1a485454505f494e5445524e414c5f5345525645525f4552524f5212076572726b696e641a0d0a067374617475731203353030",
+		},
+		{
+			err:  WithIncident(ctx, WithPublic(Temporary("timeout"), Public("try again", 503))),
+			want: "080e120974727920616761696e1a84010a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f12580a18485454505f534552564943455f554e415641494c41424c4512076572726b696e641a110a08696e636964656e7412057265712d311a0d0a0673746174757312033530331a110a0974656d706f72617279120474727565",
+		},
+		{
+			err:  Public("i'm a teapot", 418),
+			want: "0809120c69276d206120746561706f741a570a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f122b0a11485454505f495f4d5f415f544541504f5412076572726b696e641a0d0a067374617475731203343138",
+		},
+		{
+			err:  MethodNotAllowed([]string{"GET"}),
+			want: "080912126d6574686f64206e6f7420616c6c6f7765641a5d0a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f12310a17485454505f4d4554484f445f4e4f545f414c4c4f57454412076572726b696e641a0d0a067374617475731203343035",
+		},
+		{
+			err:  Public("bad gateway", 502),
+			want: "080d120b62616420676174657761791a560a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f122a0a10485454505f4241445f4741544557415912076572726b696e641a0d0a067374617475731203353032",
+		},
+	}
+	for i, tt := range tests {
+		if got, want := hex.EncodeToString(EncodeRPCStatus(tt.err)), tt.want; got != want {
+			t.Errorf("%d:\nwant=%v\n got=%v", i, want, got)
+		}
+	}
+}
+
+func TestDecodeRPCStatus(t *testing.T) {
+	tests := []struct {
+		data       string
+		nilErr     bool
+		errText    string
+		status     int
+		code       string
+		temporary  bool
+		incident   string
+		publicView View
+	}{
+		{
+			data:   "",
+			nilErr: true,
+		},
+		{
+			data:       "080512096e6f7420666f756e641a540a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f12280a0e485454505f4e4f545f464f554e4412076572726b696e641a0d0a067374617475731203343034",
+			errText:    "not found",
+			status:     404,
+			publicView: View{Status: 404, Message: "not found"},
+		},
+		{
+			data:       "080a1210776964676574206973206c6f636b65641a4c0a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f12200a064c6f636b656412076572726b696e641a0d0a067374617475731203343039",
+			errText:    "widget is locked code=Locked",
+			status:     409,
+			code:       "Locked",
+			publicView: View{Status: 409, Code: "Locked", Message: "widget is locked"},
+		},
+		{
+			data:       "080e120974727920616761696e1a84010a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f12580a18485454505f534552564943455f554e415641494c41424c4512076572726b696e641a110a08696e636964656e7412057265712d311a0d0a0673746174757312033530331a110a0974656d706f72617279120474727565",
+			errText:    "try again",
+			status:     503,
+			temporary:  true,
+			incident:   "req-1",
+			publicView: View{Status: 503, Message: "try again", Incident: "req-1"},
+		},
+		{
+			// status from another domain is not public
+			data:       "0805120e6275636b6574206d697373696e671a4f0a28747970652e676f6f676c65617069732e636f6d2f676f6f676c652e7270632e4572726f72496e666f12230a094e6f537563684b6579121673746f726167652e676f6f676c65617069732e636f6d",
+			errText:    "bucket missing code=NoSuchKey",
+			status:     404,
+			code:       "NoSuchKey",
+			publicView: DefaultView,
+		},
+		{
+			// no details, unknown fixed32 field
+			data:       "08031208626164206e616d654d01020304",
+			errText:    "bad name",
+			status:     400,
+			publicView: DefaultView,
+		},
+	}
+	for i, tt := range tests {
+		data, err := hex.DecodeString(tt.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := DecodeRPCStatus(data)
+		if tt.nilErr {
+			if decoded != nil {
+				t.Errorf("%d: want=nil, got=%v", i, decoded)
+			}
+			continue
+		}
+		if decoded == nil {
+			t.Errorf("%d: want=non-nil, got=nil", i)
+			continue
+		}
+		if got, want := decoded.Error(), tt.errText; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := StatusCode(decoded), tt.status; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := Code(decoded), tt.code; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := IsTemporary(decoded), tt.temporary; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := IncidentID(decoded), tt.incident; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := PublicView(decoded), tt.publicView; got != want {
+			t.Errorf("%d: want=%+v, got=%+v", i, want, got)
+		}
+	}
+}
+
+func TestRPCStatusRoundTrip(t *testing.T) {
+	tests := []error{
+		NotFound(),
+		BadRequest("invalid name"),
+		PublicWithCode("widget is locked", 409, "Locked"),
+		Public("slow down", 429),
+		Public("i'm a teapot", 418),
+		MethodNotAllowed([]string{"GET"}),
+		errors.New("cannot connect"),
+	}
+	for i, err := range tests {
+		data := EncodeRPCStatus(err)
+		decoded := DecodeRPCStatus(data)
+		if got, want := PublicView(decoded), PublicView(err); got != want {
+			t.Errorf("%d: want=%+v, got=%+v", i, want, got)
+		}
+		if got, want := EncodeRPCStatus(decoded), data; string(got) != string(want) {
+			t.Errorf("%d: want=%x, got=%x", i, want, got)
+		}
+	}
+}
+
+func TestDecodeRPCStatusInvalid(t *testing.T) {
+	tests := []string{
+		"08",         // truncated varint
+		"1205616263", // truncated bytes
+		"0d0102",     // truncated fixed32
+		"0b",         // unsupported wire type (start group)
+		"1a020a05",   // truncated detail
+	}
+	for i, tt := range tests {
+		data, err := hex.DecodeString(tt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if DecodeRPCStatus(data) == nil {
+			t.Errorf("%d: want error, got nil", i)
+		}
+	}
+}
+
+func TestRPCReason(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{404, "HTTP_NOT_FOUND"},
+		{418, "HTTP_I_M_A_TEAPOT"},
+		{499, "HTTP_499"},
+	}
+	for i, tt := range tests {
+		if got, want := rpcReason(tt.status), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
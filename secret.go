@@ -0,0 +1,172 @@
+package errkind
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jjeffery/errors"
+)
+
+// redacted is displayed in place of secret values.
+const redacted = "[redacted]"
+
+// RedactedKeys contains the key names whose values are redacted when key/value
+// pairs are attached to errors created by this package using the With method,
+// including subsequent calls to With on the error returned by With. A key
+// matches if it contains any of the names, ignoring case, so "password"
+// matches "password" and "dbPassword".
+//
+// Values attached by the With method of errors created by other packages are
+// redacted by KeyVals, and so by LogValue, Encode and the %+v verb, but they are
+// not redacted from the message returned by the Error method of those errors.
+// Use Secret for values known to be sensitive.
+//
+// RedactedKeys should only be modified during program initialization.
+var RedactedKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"authorization",
+	"cookie",
+}
+
+// SecretValue contains a sensitive value that is redacted when
+// formatted. Create a SecretValue using the Secret function.
+type SecretValue struct {
+	value interface{}
+}
+
+// Secret returns a value that is displayed as "[redacted]" when it is formatted
+// using the fmt package, marshaled as JSON or text, or logged using log/slog.
+// Use Secret for sensitive values attached to errors as key/value pairs.
+//  return errkind.BadRequest().With("user", user, "password", errkind.Secret(password))
+//
+// Use the Reveal function to retrieve the original value.
+func Secret(value interface{}) SecretValue {
+	if s, ok := value.(SecretValue); ok {
+		return s
+	}
+	return SecretValue{value: value}
+}
+
+// Reveal returns the original value of a value created by Secret. Any other value
+// is returned unchanged. Reveal is intended for debugging and tests: take care that
+// the value returned is not logged or returned to a client.
+func Reveal(value interface{}) interface{} {
+	if s, ok := value.(SecretValue); ok {
+		return s.value
+	}
+	return value
+}
+
+// String implements the fmt.Stringer interface.
+func (s SecretValue) String() string {
+	return redacted
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (s SecretValue) GoString() string {
+	return redacted
+}
+
+// Format implements the fmt.Formatter interface. The value is
+// redacted for all verbs and flags.
+func (s SecretValue) Format(f fmt.State, c rune) {
+	io.WriteString(f, redacted)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s SecretValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redacted)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s SecretValue) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// with attaches key/value pairs to err, redacting any sensitive values.
+func with(err error, keyvals []interface{}) errors.Error {
+	return withError{
+		err:   errors.Wrap(err).With(redact(keyvals)...),
+		cause: err,
+	}
+}
+
+// withError is returned by the With method of errors created by this package.
+// It implements error, causer and keyvalser interfaces. Its With method redacts
+// sensitive values, and it is formatted, marshaled and logged in the same way
+// as the other errors in this package.
+type withError struct {
+	err   errors.Error // wraps cause with the key/value pairs
+	cause error
+}
+
+func (w withError) Error() string {
+	return w.err.Error()
+}
+
+func (w withError) Cause() error {
+	return w.cause
+}
+
+func (w withError) Unwrap() error {
+	return w.cause
+}
+
+func (w withError) Keyvals() []interface{} {
+	if kv, ok := w.err.(keyvalser); ok {
+		return kv.Keyvals()
+	}
+	return nil
+}
+
+func (w withError) Format(f fmt.State, c rune) {
+	format(f, c, w)
+}
+
+func (w withError) MarshalJSON() ([]byte, error) {
+	return Encode(w)
+}
+
+func (w withError) With(keyvals ...interface{}) errors.Error {
+	w.err = w.err.With(redact(keyvals)...)
+	return w
+}
+
+// redact returns a copy of keyvals with any values whose key is in
+// RedactedKeys replaced with secret values.
+func redact(keyvals []interface{}) []interface{} {
+	var redactedKeyvals []interface{}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok || !isRedactedKey(key) {
+			continue
+		}
+		if _, ok := keyvals[i+1].(SecretValue); ok {
+			continue
+		}
+		if redactedKeyvals == nil {
+			redactedKeyvals = make([]interface{}, len(keyvals))
+			copy(redactedKeyvals, keyvals)
+		}
+		redactedKeyvals[i+1] = Secret(keyvals[i+1])
+	}
+	if redactedKeyvals == nil {
+		return keyvals
+	}
+	return redactedKeyvals
+}
+
+func isRedactedKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, name := range RedactedKeys {
+		if name != "" && strings.Contains(key, strings.ToLower(name)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,11 @@
+//go:build go1.21
+// +build go1.21
+
+package errkind
+
+import "log/slog"
+
+// LogValue implements the slog.LogValuer interface.
+func (s SecretValue) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
@@ -0,0 +1,32 @@
+//go:build go1.21
+// +build go1.21
+
+package errkind
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestSecretLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("login", "user", "bob", "password", Secret("xyzzy"))
+	if got, want := buf.String(), `{"level":"INFO","msg":"login","user":"bob","password":"[redacted]"}`+"\n"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+
+	buf.Reset()
+	logger.Info("login", "err", NotFound().With("user", "bob").With("password", "xyzzy"))
+	if got, want := buf.String(), `{"level":"INFO","msg":"login","err":{"msg":"not found user=bob password=[redacted]","status":404,"user":"bob","password":"[redacted]"}}`+"\n"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
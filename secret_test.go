@@ -0,0 +1,129 @@
+package errkind
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestSecret(t *testing.T) {
+	secret := Secret("xyzzy")
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%d"} {
+		if got, want := fmt.Sprintf(format, secret), "[redacted]"; got != want {
+			t.Errorf("%s: want=%v, got=%v", format, want, got)
+		}
+	}
+	if got, want := fmt.Sprint(secret), "[redacted]"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	data, err := json.Marshal(map[string]interface{}{"password": secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"password":"[redacted]"}`; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := Reveal(secret), interface{}("xyzzy"); got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := Reveal(Secret(secret)), interface{}("xyzzy"); got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := Reveal(42), interface{}(42); got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		err       error
+		wantError string
+	}{
+		{
+			err:       BadRequest().With("user", "bob", "password", "xyzzy"),
+			wantError: "bad request user=bob password=[redacted]",
+		},
+		{
+			err:       NotFound().With("accessToken", "abc", "Authorization", "Bearer abc"),
+			wantError: "not found accessToken=[redacted] Authorization=[redacted]",
+		},
+		{
+			err:       Public("public", 400).With("key", Secret("xyzzy")),
+			wantError: "public key=[redacted]",
+		},
+		{
+			err:       PublicWithCode("public", 400, "CODE").With("dbPassword", "xyzzy"),
+			wantError: "public code=CODE dbPassword=[redacted]",
+		},
+		{
+			err:       Temporary("temp").With("token", "abc"),
+			wantError: "temp token=[redacted]",
+		},
+		{
+			err:       WithPublic(errors.New("internal"), NotFound()).With("secret", "abc"),
+			wantError: "internal secret=[redacted]",
+		},
+		{
+			err:       NotFound().With("id", 1).With("password", "hunter2"),
+			wantError: "not found id=1 password=[redacted]",
+		},
+		{
+			err:       errors.Wrap(Forbidden(), "wrapped").With("password", Secret("xyzzy")),
+			wantError: "wrapped password=[redacted]: forbidden",
+		},
+	}
+	for i, tt := range tests {
+		if got, want := tt.err.Error(), tt.wantError; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestRedactKeyvals(t *testing.T) {
+	keyvals := []interface{}{"user", "bob", "password", "xyzzy", 1, 2}
+	redacted := redact(keyvals)
+	if got, want := fmt.Sprint(redacted), "[user bob password [redacted] 1 2]"; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := fmt.Sprint(keyvals), "[user bob password xyzzy 1 2]"; got != want {
+		t.Errorf("original modified: want=%v, got=%v", want, got)
+	}
+	if got, want := Reveal(redacted[3]), interface{}("xyzzy"); got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
+
+func TestRedactKeyVals(t *testing.T) {
+	tests := []struct {
+		err      error
+		wantJSON string
+	}{
+		{
+			err:      NotFound().With("id", 1).With("password", "hunter2"),
+			wantJSON: `["id",1,"password","[redacted]"]`,
+		},
+		{
+			err:      errors.Wrap(NotFound(), "wrapped").With("token", "abc"),
+			wantJSON: `["token","[redacted]"]`,
+		},
+	}
+	for i, tt := range tests {
+		data, err := json.Marshal(KeyVals(tt.err))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(data), tt.wantJSON; got != want {
+			t.Errorf("%d: KeyVals: want=%v, got=%v", i, want, got)
+		}
+		data, err = Encode(tt.err)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), `"keyvals":`+tt.wantJSON) {
+			t.Errorf("%d: Encode: want keyvals=%v, got=%s", i, tt.wantJSON, data)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package errkind
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// SeverityLevel indicates how serious an error is, so that logging and
+// alerting can treat errors differently. For example, an error that is
+// expected during normal operation (such as a client requesting a resource
+// that does not exist) does not need the attention of an operator.
+type SeverityLevel int
+
+// Severity levels, from least to most severe. The zero value
+// indicates that the severity is not known.
+const (
+	SeverityDebug    SeverityLevel = iota + 1 // of interest during development only
+	SeverityInfo                              // expected during normal operation
+	SeverityWarning                           // may need attention if it persists
+	SeverityError                             // needs attention
+	SeverityCritical                          // needs immediate attention
+)
+
+var severityNames = map[SeverityLevel]string{
+	SeverityDebug:    "debug",
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityError:    "error",
+	SeverityCritical: "critical",
+}
+
+// String implements the fmt.Stringer interface.
+func (s SeverityLevel) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("SeverityLevel(%d)", int(s))
+}
+
+// severitier is an interface implemented by errors that have a severity.
+type severitier interface {
+	Severity() SeverityLevel
+}
+
+// Severity returns the severity that was set when the error was created.
+func (d *details) Severity() SeverityLevel {
+	if d == nil {
+		return 0
+	}
+	return d.severity
+}
+
+// SetSeverity returns an option that sets the severity of an error,
+// overriding the default severity reported by Severity.
+//  err = errkind.WithOptions(err, errkind.SetSeverity(errkind.SeverityCritical))
+func SetSeverity(level SeverityLevel) Option {
+	return func(d *details) {
+		d.severity = level
+	}
+}
+
+// Severity returns the severity of err. If the severity of err, or one of
+// its causes, has been set (see SetSeverity), the severity closest to err
+// is returned. Otherwise the severity is derived from the error:
+//  SeverityWarning  if the error is temporary (see IsTemporary)
+//  SeverityInfo     if the status code is less than 500, such as client errors
+//  SeverityError    for any other error, including errors without a status code
+//
+// An error has a severity if it, or one of its causes,
+// implements the following interface and returns a non-zero severity.
+//  type severitier interface {
+//      Severity() SeverityLevel
+//  }
+//
+// If err is nil, Severity returns zero.
+func Severity(err error) SeverityLevel {
+	if err == nil {
+		return 0
+	}
+	var severity SeverityLevel
+	walk(err, func(err error) bool {
+		if s, ok := err.(severitier); ok {
+			severity = s.Severity()
+		}
+		return severity != 0
+	})
+	if severity != 0 {
+		return severity
+	}
+	if IsTemporary(err) {
+		return SeverityWarning
+	}
+	if status := StatusCode(err); status != 0 && status < http.StatusInternalServerError {
+		return SeverityInfo
+	}
+	return SeverityError
+}
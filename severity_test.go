@@ -0,0 +1,88 @@
+package errkind
+
+import (
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		err  error
+		want SeverityLevel
+	}{
+		{err: nil, want: 0},
+		{err: errors.New("cannot connect"), want: SeverityError},
+		{err: NotFound(), want: SeverityInfo},
+		{err: errors.Wrap(BadRequest(), "wrapped"), want: SeverityInfo},
+		{err: testingStatusError(302), want: SeverityInfo},
+		{err: NotImplemented(), want: SeverityError},
+		{err: Public("down for maintenance", 503), want: SeverityError},
+		{err: Temporary("timeout"), want: SeverityWarning},
+		{err: WithOptions(NotFound(), SetSeverity(SeverityWarning)), want: SeverityWarning},
+		{err: errors.Wrap(WithOptions(NotFound(), SetSeverity(SeverityDebug)), "wrapped"), want: SeverityDebug},
+		{err: WithOptions(errors.New("disk full"), SetSeverity(SeverityCritical)), want: SeverityCritical},
+		{
+			err:  WithOptions(errors.Wrap(WithOptions(NotFound(), SetSeverity(SeverityDebug)), "wrapped"), SetSeverity(SeverityError)),
+			want: SeverityError,
+		},
+		{err: WithPublic(Temporary("timeout"), NotFound()), want: SeverityWarning},
+	}
+	for i, tt := range tests {
+		if got, want := Severity(tt.err), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestWithOptions(t *testing.T) {
+	if got := WithOptions(nil, SetSeverity(SeverityCritical)); got != nil {
+		t.Errorf("want=nil, got=%v", got)
+	}
+
+	// errors created by this package are copied
+	notFound := NotFound()
+	err := WithOptions(notFound, SetSeverity(SeverityCritical))
+	if _, ok := err.(statusError); !ok {
+		t.Errorf("want=statusError, got=%T", err)
+	}
+	if got, want := Severity(notFound), SeverityInfo; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+
+	// other errors are wrapped
+	cause := errors.New("cannot connect")
+	err = WithOptions(cause, SetSeverity(SeverityCritical))
+	if got, want := err.Error(), cause.Error(); got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := errors.Cause(err), cause; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	err = WithOptions(err, SetSeverity(SeverityWarning))
+	if got, want := errors.Cause(err), cause; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+	if got, want := Severity(err), SeverityWarning; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
+
+func TestSeverityLevelString(t *testing.T) {
+	tests := []struct {
+		level SeverityLevel
+		want  string
+	}{
+		{level: SeverityDebug, want: "debug"},
+		{level: SeverityInfo, want: "info"},
+		{level: SeverityWarning, want: "warning"},
+		{level: SeverityError, want: "error"},
+		{level: SeverityCritical, want: "critical"},
+		{level: 0, want: "SeverityLevel(0)"},
+	}
+	for i, tt := range tests {
+		if got, want := tt.level.String(), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+//go:build go1.21
+// +build go1.21
+
+package errkind
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// LogValue implements the slog.LogValuer interface.
+func (s statusError) LogValue() slog.Value {
+	return LogValue(s)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (s publicStatusError) LogValue() slog.Value {
+	return LogValue(s)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (s publicStatusCodeError) LogValue() slog.Value {
+	return LogValue(s)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (t temporaryError) LogValue() slog.Value {
+	return LogValue(t)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (t temporaryStatusError) LogValue() slog.Value {
+	return LogValue(t)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (t temporaryPublicError) LogValue() slog.Value {
+	return LogValue(t)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (t temporaryPublicCodeError) LogValue() slog.Value {
+	return LogValue(t)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (m maskedError) LogValue() slog.Value {
+	return LogValue(m)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (d detailsError) LogValue() slog.Value {
+	return LogValue(d)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (p permanentError) LogValue() slog.Value {
+	return LogValue(p)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (d decodedError) LogValue() slog.Value {
+	return LogValue(d)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (d publicDecodedError) LogValue() slog.Value {
+	return LogValue(d)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (d decodedWrapper) LogValue() slog.Value {
+	return LogValue(d)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (i incidentError) LogValue() slog.Value {
+	return LogValue(i)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (j *joinError) LogValue() slog.Value {
+	return LogValue(j)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (w withError) LogValue() slog.Value {
+	return LogValue(w)
+}
+
+// Level implements the slog.Leveler interface, so that errors can be
+// logged at a level that matches their severity.
+//  logger.Log(ctx, errkind.Severity(err).Level(), "cannot get widget", "err", err)
+//
+// SeverityCritical is one level above slog.LevelError, and
+// unknown severities are logged at slog.LevelError.
+func (s SeverityLevel) Level() slog.Level {
+	switch s {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarning:
+		return slog.LevelWarn
+	case SeverityCritical:
+		return slog.LevelError + 4
+	}
+	return slog.LevelError
+}
+
+// LogValue returns a group value describing err, suitable for logging with
+// the log/slog package. The group contains the following attributes:
+//  msg        the error message
+//  status     the status code, if any (see StatusCode)
+//  code       the code, if any (see Code)
+//  temporary  true if the error is temporary (see IsTemporary)
+//  public     true if the message is public (see PublicView)
+// followed by the key/value pairs attached to err and its causes, from the
+// outermost error to the innermost error. This includes the "caller" key
+// attached by NotImplemented. Attributes with zero values are omitted.
+//
+// Errors created by this package implement slog.LogValuer using LogValue.
+// Use NewLogHandler to expand other errors in the same way.
+func LogValue(err error) slog.Value {
+	if err == nil {
+		return slog.Value{}
+	}
+	attrs := []slog.Attr{slog.String("msg", err.Error())}
+	if status := StatusCode(err); status != 0 {
+		attrs = append(attrs, slog.Int("status", status))
+	}
+	if code := Code(err); code != "" {
+		attrs = append(attrs, slog.String("code", code))
+	}
+	if IsTemporary(err) {
+		attrs = append(attrs, slog.Bool("temporary", true))
+	}
+	if HasPublicMessage(cause(err)) {
+		attrs = append(attrs, slog.Bool("public", true))
+	}
+	keyvals := KeyVals(err)
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		attrs = append(attrs, expandAttr(slog.Any(key, keyvals[i+1])))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// logHandler is a slog.Handler that expands error attributes
+// before passing them to the next handler.
+type logHandler struct {
+	next slog.Handler
+}
+
+// NewLogHandler returns a slog.Handler that expands any attributes with
+// error values into groups using LogValue, before passing them to next.
+// Error attributes are expanded wherever they appear, including inside
+// groups and in attributes added using slog.Logger.With.
+//  logger := slog.New(errkind.NewLogHandler(slog.NewJSONHandler(os.Stderr, nil)))
+//  logger.Error("cannot get widget", "err", err)
+func NewLogHandler(next slog.Handler) slog.Handler {
+	return logHandler{next: next}
+}
+
+func (h logHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h logHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		record.AddAttrs(expandAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, record)
+}
+
+func (h logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = expandAttr(a)
+	}
+	return logHandler{next: h.next.WithAttrs(expanded)}
+}
+
+func (h logHandler) WithGroup(name string) slog.Handler {
+	return logHandler{next: h.next.WithGroup(name)}
+}
+
+// expandAttr expands error values in the attribute, including
+// error values inside groups.
+func expandAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			a.Value = LogValue(err)
+		}
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			attrs[i] = expandAttr(ga)
+		}
+		a.Value = slog.GroupValue(attrs...)
+	}
+	return a
+}
@@ -0,0 +1,142 @@
+//go:build go1.21
+// +build go1.21
+
+package errkind
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+// newTestLogger returns a logger that writes JSON to buf without timestamps.
+func newTestLogger(buf *bytes.Buffer, expand bool) *slog.Logger {
+	var h slog.Handler = slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	if expand {
+		h = NewLogHandler(h)
+	}
+	return slog.New(h)
+}
+
+func TestLogValue(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{
+			err:  NotFound(),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"not found","status":404}}`,
+		},
+		{
+			err:  PublicWithCode("widget is locked", 409, "Locked"),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"widget is locked code=Locked","status":409,"code":"Locked","public":true}}`,
+		},
+		{
+			err:  Temporary("timeout"),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"timeout","temporary":true}}`,
+		},
+		{
+			err:  WithPublic(errors.New("db down"), Public("unavailable", 503)),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"db down","status":503,"public":true}}`,
+		},
+		{
+			err:  WithIncident(ContextWithRequestID(context.Background(), "req-1"), NotFound()),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"not found incident=req-1","status":404,"incident":"req-1"}}`,
+		},
+		{
+			err:  Join(NotFound().With("id", 1), ServiceUnavailable()),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"not found id=1; service unavailable","status":503,"id":1}}`,
+		},
+		{
+			err:  Permanent(Temporary("timeout")),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"timeout"}}`,
+		},
+		{
+			err:  WithOptions(errors.New("not errkind"), SetSeverity(SeverityCritical)),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"not errkind"}}`,
+		},
+		{
+			err:  Decode([]byte(`{"error":"widget is locked code=Locked","message":"widget is locked","status":409,"code":"Locked","public":["message","status","code"],"keyvals":["id",42]}`)),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"widget is locked code=Locked","status":409,"code":"Locked","public":true,"id":42}}`,
+		},
+		{
+			err:  Decode([]byte(`{"error":"cannot update: not found","message":"not found","status":404,"public":["status"],"wrapped":true}`)),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"cannot update: not found","status":404}}`,
+		},
+		{
+			err:  BadRequest().With("id", 1),
+			want: `{"level":"INFO","msg":"test","err":{"msg":"bad request id=1","status":400,"id":1}}`,
+		},
+		{
+			// not expanded without the handler
+			err:  errors.New("not errkind").With("id", 1),
+			want: `{"level":"INFO","msg":"test","err":"not errkind id=1"}`,
+		},
+	}
+	for i, tt := range tests {
+		var buf bytes.Buffer
+		newTestLogger(&buf, false).Info("test", "err", tt.err)
+		if got, want := strings.TrimSpace(buf.String()), tt.want; got != want {
+			t.Errorf("%d:\nwant=%v\n got=%v", i, want, got)
+		}
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf, true)
+
+	err := errors.Wrap(BadRequest().With("id", 1, "password", "xyzzy"), "cannot get widget").With("user", "bob")
+	logger.Info("test", "err", err)
+	want := `{"level":"INFO","msg":"test","err":{"msg":"cannot get widget user=bob: bad request id=1 password=[redacted]","status":400,"user":"bob","id":1,"password":"[redacted]"}}`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("\nwant=%v\n got=%v", want, got)
+	}
+
+	buf.Reset()
+	logger.With("err", Temporary("timeout")).Info("test", slog.Group("req", "err", errors.New("no status")))
+	want = `{"level":"INFO","msg":"test","err":{"msg":"timeout","temporary":true},"req":{"err":{"msg":"no status"}}}`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("\nwant=%v\n got=%v", want, got)
+	}
+
+	buf.Reset()
+	logger.WithGroup("g").Info("test", "n", 1, "err", NotImplemented())
+	got := strings.TrimSpace(buf.String())
+	if want := `"g":{"n":1,"err":{"msg":"not implemented caller=`; !strings.Contains(got, want) {
+		t.Errorf("\nwant=%v\n got=%v", want, got)
+	}
+	if want := `"status":501,"caller":"slog_test.go:`; !strings.Contains(got, want) {
+		t.Errorf("\nwant=%v\n got=%v", want, got)
+	}
+}
+
+func TestSeverityLevel(t *testing.T) {
+	tests := []struct {
+		level SeverityLevel
+		want  slog.Level
+	}{
+		{level: SeverityDebug, want: slog.LevelDebug},
+		{level: SeverityInfo, want: slog.LevelInfo},
+		{level: SeverityWarning, want: slog.LevelWarn},
+		{level: SeverityError, want: slog.LevelError},
+		{level: SeverityCritical, want: slog.LevelError + 4},
+		{level: 0, want: slog.LevelError},
+	}
+	for i, tt := range tests {
+		if got, want := tt.level.Level(), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
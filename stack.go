@@ -0,0 +1,122 @@
+package errkind
+
+import (
+	"net/http"
+
+	"github.com/go-stack/stack"
+	"github.com/jjeffery/errors"
+)
+
+// CaptureStack determines whether the constructors in this package capture
+// a stack trace when they create an error. It is off by default, because
+// capturing a stack trace is relatively expensive. Use WithStack to capture
+// a stack trace for an individual error.
+//
+// CaptureStack should only be modified during program initialization.
+var CaptureStack = false
+
+// stackTracer is an interface implemented by errors that have a stack trace.
+type stackTracer interface {
+	StackTrace() stack.CallStack
+}
+
+// details contains optional details that are common to all errors
+// created by this package. Errors refer to details using a pointer, which
+// is nil if there are no details, so errors remain cheap to create and
+// can be compared.
+type details struct {
+	stack          stack.CallStack
+	severity       SeverityLevel
+	maxRetries     int
+	challenges     []Challenge
+	header         http.Header
+	allowedMethods []string
+}
+
+// StackTrace returns the stack trace captured when the error was
+// created, or nil if no stack trace was captured.
+func (d *details) StackTrace() stack.CallStack {
+	if d == nil {
+		return nil
+	}
+	return d.stack
+}
+
+// clone returns a copy of d, which may be nil.
+func (d *details) clone() *details {
+	if d == nil {
+		return &details{}
+	}
+	clone := *d
+	return &clone
+}
+
+// detailer is an interface implemented by errors in this package,
+// which allows their details to be modified.
+type detailer interface {
+	// withDetails returns a copy of the error with details
+	// modified by the function.
+	withDetails(func(*details)) errors.Error
+}
+
+// newDetails returns the details for a new error. The argument skip is the
+// number of stack frames to skip before capturing a stack trace, with 0
+// identifying the caller of newDetails.
+func newDetails(skip int) *details {
+	if !CaptureStack {
+		return nil
+	}
+	return &details{stack: callers(skip + 1)}
+}
+
+// callers returns the call stack starting at the caller identified by skip,
+// with 0 identifying the caller of callers.
+func callers(skip int) stack.CallStack {
+	return stack.Trace().TrimBelow(stack.Caller(skip + 1)).TrimRuntime()
+}
+
+// WithStack returns err with a stack trace captured at the point
+// WithStack is called. It is useful for capturing a stack trace for
+// an individual error when CaptureStack is off.
+//  return errkind.WithStack(errkind.NotFound())
+//
+// If err was created by this package, the returned error is a copy of err
+// with the stack trace, and is otherwise identical to err. Other errors
+// are wrapped with an error that has the stack trace, and whose cause is err.
+//
+// If err is nil, WithStack returns nil.
+func WithStack(err error) errors.Error {
+	if err == nil {
+		return nil
+	}
+	cs := callers(1)
+	if d, ok := err.(detailer); ok {
+		return d.withDetails(func(d *details) {
+			d.stack = cs
+		})
+	}
+	return detailsError{
+		err:     err,
+		details: &details{stack: cs},
+	}
+}
+
+// StackTrace returns the stack trace captured when err, or one of its causes,
+// was created. If more than one error in the chain of causes has a stack
+// trace, the stack trace closest to the cause is returned, as it is the most
+// complete. StackTrace returns nil if no stack trace was captured.
+//
+// Stack traces are captured if CaptureStack is true when the error is created,
+// or if the error is created using WithStack.
+func StackTrace(err error) stack.CallStack {
+	var cs stack.CallStack
+	walk(err, func(err error) bool {
+		if st, ok := err.(stackTracer); ok {
+			if s := st.StackTrace(); len(s) > 0 {
+				cs = s
+			}
+		}
+		return false
+	})
+	return cs
+}
@@ -0,0 +1,116 @@
+package errkind
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestStackTrace(t *testing.T) {
+	defer func(capture bool) { CaptureStack = capture }(CaptureStack)
+
+	CaptureStack = false
+	if st := StackTrace(NotFound()); st != nil {
+		t.Errorf("want=nil, got=%v", st)
+	}
+	if got, want := NotFound(), NotFound(); got != want {
+		t.Errorf("want comparable errors, got=%v, want=%v", got, want)
+	}
+
+	CaptureStack = true
+	tests := []struct {
+		err error
+	}{
+		{err: BadRequest()},
+		{err: Unauthorized()},
+		{err: Forbidden()},
+		{err: NotFound()},
+		{err: NotImplemented()},
+		{err: Public("public", 400)},
+		{err: PublicWithCode("public", 400, "")},
+		{err: PublicWithCode("public", 400, "CODE")},
+		{err: Temporary("temporary")},
+		{err: ServiceUnavailable()},
+		{err: TemporaryWithStatus("temporary", 503)},
+		{err: TemporaryWithStatus("temporary", 503, SetMaxRetries(3))},
+		{err: TemporaryPublic("temporary", 503, "")},
+		{err: TemporaryPublic("temporary", 503, "CODE", SetMaxRetries(3))},
+		{err: Join(errors.New("first"), errors.New("second"))},
+		{err: Permanent(errors.New("permanent"))},
+		{err: WithPublic(errors.New("internal"), NotFound())},
+		{err: Mask(errors.New("internal"), NotFound())},
+		{err: errors.Wrap(NotFound().With("a", "b"), "wrapped")},
+	}
+	for i, tt := range tests {
+		st := StackTrace(tt.err)
+		if len(st) == 0 {
+			t.Errorf("%d: want stack trace, got none", i)
+			continue
+		}
+		if got, want := fmt.Sprintf("%n", st[0]), "TestStackTrace"; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestWithStack(t *testing.T) {
+	if got := WithStack(nil); got != nil {
+		t.Errorf("want=nil, got=%v", got)
+	}
+
+	tests := []struct {
+		err        error
+		wantError  string
+		wantStatus int
+	}{
+		{
+			err:        WithStack(NotFound()),
+			wantError:  "not found",
+			wantStatus: 404,
+		},
+		{
+			err:        WithStack(errors.New("not errkind")),
+			wantError:  "not errkind",
+			wantStatus: 0,
+		},
+		{
+			err:        WithStack(errors.Wrap(Forbidden(), "wrapped")).With("a", 1),
+			wantError:  "wrapped: forbidden a=1",
+			wantStatus: 403,
+		},
+	}
+	for i, tt := range tests {
+		if got, want := tt.err.Error(), tt.wantError; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := fmt.Sprintf("%v", tt.err), tt.wantError; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := StatusCode(tt.err), tt.wantStatus; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		st := StackTrace(tt.err)
+		if len(st) == 0 {
+			t.Errorf("%d: want stack trace, got none", i)
+			continue
+		}
+		if got, want := fmt.Sprintf("%n", st[0]), "TestWithStack"; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+
+	// public error remains public
+	err := WithStack(Public("public", 400))
+	if !HasPublicMessage(err) {
+		t.Errorf("want public message")
+	}
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(verbose, "public\n") || !strings.Contains(verbose, "TestWithStack\n\t") {
+		t.Errorf("want stack trace, got=%s", verbose)
+	}
+	if got, want := fmt.Sprintf("%s|%q", err, err), `public|"public"`; got != want {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
@@ -0,0 +1,62 @@
+package errkind
+
+import "net/http"
+
+// statusOrDefault returns the status code of err, or 500 (internal server
+// error) if err does not have a status code.
+func statusOrDefault(err error) int {
+	if status := StatusCode(err); status != 0 {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// StatusClass returns the class of the status code associated with err,
+// which is the first digit of the status code: 4 for client errors (4xx)
+// and 5 for server errors (5xx).
+//
+// An error that does not have a status code is treated as if its status
+// code were 500 (internal server error), so its class is 5. This is consistent
+// with PublicView, which reports errors without a public status code
+// as internal server errors. If err is nil, StatusClass returns zero.
+func StatusClass(err error) int {
+	if err == nil {
+		return 0
+	}
+	return statusOrDefault(err) / 100
+}
+
+// HasStatusCodeIn determines whether the status code associated with err
+// is in the range lo to hi, inclusive.
+//  if errkind.HasStatusCodeIn(err, 400, 499) {
+//      // client error
+//  }
+//
+// An error that does not have a status code is treated as if its status
+// code were 500 (internal server error). If err is nil, HasStatusCodeIn
+// returns false.
+func HasStatusCodeIn(err error, lo, hi int) bool {
+	if err == nil {
+		return false
+	}
+	status := statusOrDefault(err)
+	return status >= lo && status <= hi
+}
+
+// IsClientError returns true if the status code associated with err
+// is a client error (4xx), which indicates that the request should not
+// be repeated without modification.
+//
+// An error that does not have a status code is not a client error.
+func IsClientError(err error) bool {
+	return StatusClass(err) == 4
+}
+
+// IsServerError returns true if the status code associated with err
+// is a server error (5xx).
+//
+// An error that does not have a status code is a server error, because it
+// is treated as if its status code were 500 (internal server error).
+func IsServerError(err error) bool {
+	return StatusClass(err) == 5
+}
@@ -0,0 +1,57 @@
+package errkind
+
+import (
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestStatusClass(t *testing.T) {
+	tests := []struct {
+		err         error
+		class       int
+		clientError bool
+		serverError bool
+	}{
+		{err: nil, class: 0},
+		{err: errors.New("no status"), class: 5, serverError: true},
+		{err: Temporary("timeout"), class: 5, serverError: true},
+		{err: BadRequest(), class: 4, clientError: true},
+		{err: errors.Wrap(NotFound(), "cannot get"), class: 4, clientError: true},
+		{err: Public("teapot", 418), class: 4, clientError: true},
+		{err: NotImplemented(), class: 5, serverError: true},
+		{err: testingStatusError(302), class: 3},
+	}
+	for i, tt := range tests {
+		if got, want := StatusClass(tt.err), tt.class; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := IsClientError(tt.err), tt.clientError; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := IsServerError(tt.err), tt.serverError; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestHasStatusCodeIn(t *testing.T) {
+	tests := []struct {
+		err    error
+		lo, hi int
+		want   bool
+	}{
+		{err: nil, lo: 0, hi: 999, want: false},
+		{err: NotFound(), lo: 400, hi: 499, want: true},
+		{err: NotFound(), lo: 404, hi: 404, want: true},
+		{err: NotFound(), lo: 405, hi: 499, want: false},
+		{err: NotFound(), lo: 500, hi: 599, want: false},
+		{err: errors.New("no status"), lo: 500, hi: 599, want: true},
+		{err: errors.New("no status"), lo: 400, hi: 499, want: false},
+	}
+	for i, tt := range tests {
+		if got, want := HasStatusCodeIn(tt.err, tt.lo, tt.hi), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
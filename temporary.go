@@ -0,0 +1,197 @@
+package errkind
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jjeffery/errors"
+)
+
+// maxRetrier is an interface implemented by errors that have
+// a hint for the maximum number of retry attempts.
+type maxRetrier interface {
+	MaxRetries() int
+}
+
+// MaxRetries returns the maximum number of retry attempts that was set
+// when the error was created, or zero if there is no hint.
+func (d *details) MaxRetries() int {
+	if d == nil {
+		return 0
+	}
+	return d.maxRetries
+}
+
+// SetMaxRetries returns an option that sets a hint for the maximum number
+// of times that the operation that caused a temporary error should be retried.
+//  return errkind.TemporaryWithStatus("database is busy", 503, errkind.SetMaxRetries(3))
+func SetMaxRetries(n int) Option {
+	return func(d *details) {
+		d.maxRetries = n
+	}
+}
+
+// MaxRetries returns the hint for the maximum number of retry attempts set for
+// err or one of its causes (see SetMaxRetries), or zero if there is no hint.
+// The hint closest to err is returned.
+//
+// An error has a hint if it, or one of its causes, implements the
+// following interface and returns a non-zero number of attempts.
+//  type maxRetrier interface {
+//      MaxRetries() int
+//  }
+func MaxRetries(err error) int {
+	var n int
+	walk(err, func(err error) bool {
+		if mr, ok := err.(maxRetrier); ok {
+			n = mr.MaxRetries()
+		}
+		return n != 0
+	})
+	return n
+}
+
+// temporaryStatusError implements error, statusCoder, publicStatusCoder
+// and temporaryer interfaces.
+type temporaryStatusError struct {
+	statusError
+}
+
+func (t temporaryStatusError) Temporary() bool {
+	return true
+}
+
+func (t temporaryStatusError) Format(f fmt.State, c rune) {
+	format(f, c, t)
+}
+
+func (t temporaryStatusError) MarshalJSON() ([]byte, error) {
+	return Encode(t)
+}
+
+func (t temporaryStatusError) With(keyvals ...interface{}) errors.Error {
+	return with(t, keyvals)
+}
+
+func (t temporaryStatusError) withDetails(fn func(*details)) errors.Error {
+	t.details = t.details.clone()
+	fn(t.details)
+	return t
+}
+
+// temporaryPublicError implements error, statusCoder, publicMessager,
+// publicStatusCoder and temporaryer interfaces.
+type temporaryPublicError struct {
+	publicStatusError
+}
+
+func (t temporaryPublicError) Temporary() bool {
+	return true
+}
+
+func (t temporaryPublicError) Format(f fmt.State, c rune) {
+	format(f, c, t)
+}
+
+func (t temporaryPublicError) MarshalJSON() ([]byte, error) {
+	return Encode(t)
+}
+
+func (t temporaryPublicError) With(keyvals ...interface{}) errors.Error {
+	return with(t, keyvals)
+}
+
+func (t temporaryPublicError) withDetails(fn func(*details)) errors.Error {
+	t.details = t.details.clone()
+	fn(t.details)
+	return t
+}
+
+// temporaryPublicCodeError implements error, statusCoder, coder, publicMessager,
+// publicStatusCoder, publicCoder and temporaryer interfaces.
+type temporaryPublicCodeError struct {
+	publicStatusCodeError
+}
+
+func (t temporaryPublicCodeError) Temporary() bool {
+	return true
+}
+
+func (t temporaryPublicCodeError) Format(f fmt.State, c rune) {
+	format(f, c, t)
+}
+
+func (t temporaryPublicCodeError) MarshalJSON() ([]byte, error) {
+	return Encode(t)
+}
+
+func (t temporaryPublicCodeError) With(keyvals ...interface{}) errors.Error {
+	return with(t, keyvals)
+}
+
+func (t temporaryPublicCodeError) withDetails(fn func(*details)) errors.Error {
+	t.details = t.details.clone()
+	fn(t.details)
+	return t
+}
+
+// ServiceUnavailable returns a temporary error that has a status
+// of 503 (service unavailable).
+//
+// The returned error has a PublicStatusCode() method, which indicates that the
+// status code is public and can be returned to a client.
+func ServiceUnavailable(msg ...string) errors.Error {
+	return temporaryStatusError{
+		statusError{
+			message: makeMessage("service unavailable", msg),
+			status:  http.StatusServiceUnavailable,
+			details: newDetails(1),
+		},
+	}
+}
+
+// TemporaryWithStatus returns a temporary error with the message and status.
+// Use options to provide additional details, such as SetMaxRetries.
+//
+// The returned error has a PublicStatusCode() method, which indicates that the
+// status code is public and can be returned to a client. The message is not
+// public: use TemporaryPublic for a temporary error with a public message.
+func TemporaryWithStatus(msg string, status int, opts ...Option) errors.Error {
+	return temporaryStatusError{
+		statusError{
+			message: msg,
+			status:  status,
+			details: newDetailsWith(1, opts),
+		},
+	}
+}
+
+// TemporaryPublic returns a temporary error with the message, status and code.
+// Use options to provide additional details, such as SetMaxRetries.
+//
+// The returned error is the same as the error returned by PublicWithCode,
+// except that it is temporary: it has PublicMessage(), PublicStatusCode() and
+// PublicCode() methods, which indicate that the message, status and code are
+// public and can be returned to a client. If code is blank, the returned error
+// has no code.
+func TemporaryPublic(msg string, status int, code string, opts ...Option) errors.Error {
+	if code == "" {
+		return temporaryPublicError{
+			publicStatusError{
+				statusError{
+					message: msg,
+					status:  status,
+					details: newDetailsWith(1, opts),
+				},
+			},
+		}
+	}
+	return temporaryPublicCodeError{
+		publicStatusCodeError{
+			message: msg,
+			status:  status,
+			code:    code,
+			details: newDetailsWith(1, opts),
+		},
+	}
+}
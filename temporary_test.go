@@ -0,0 +1,126 @@
+package errkind
+
+import (
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestTemporaryWithStatus(t *testing.T) {
+	tests := []struct {
+		err              error
+		errText          string
+		status           int
+		code             string
+		publicMessage    bool
+		publicStatusCode bool
+		publicCode       bool
+		maxRetries       int
+		view             View
+	}{
+		{
+			err:              ServiceUnavailable(),
+			errText:          "service unavailable",
+			status:           503,
+			publicStatusCode: true,
+			view:             View{Status: 503, Message: "service unavailable"},
+		},
+		{
+			err:              ServiceUnavailable("database is down"),
+			errText:          "database is down",
+			status:           503,
+			publicStatusCode: true,
+			view:             View{Status: 503, Message: "service unavailable"},
+		},
+		{
+			err:              TemporaryWithStatus("rate limited by upstream", 429, SetMaxRetries(5)),
+			errText:          "rate limited by upstream",
+			status:           429,
+			publicStatusCode: true,
+			maxRetries:       5,
+			view:             View{Status: 429, Message: "too many requests"},
+		},
+		{
+			err:              TemporaryPublic("try again later", 503, ""),
+			errText:          "try again later",
+			status:           503,
+			publicMessage:    true,
+			publicStatusCode: true,
+			view:             View{Status: 503, Message: "try again later"},
+		},
+		{
+			err:              TemporaryPublic("widget is busy", 409, "Busy", SetMaxRetries(3)),
+			errText:          "widget is busy code=Busy",
+			status:           409,
+			code:             "Busy",
+			publicMessage:    true,
+			publicStatusCode: true,
+			publicCode:       true,
+			maxRetries:       3,
+			view:             View{Status: 409, Code: "Busy", Message: "widget is busy"},
+		},
+		{
+			err:              TemporaryPublic("widget is busy", 409, "Busy").With("id", 42),
+			errText:          "widget is busy code=Busy id=42",
+			status:           409,
+			code:             "Busy",
+			publicMessage:    true,
+			publicStatusCode: true,
+			publicCode:       true,
+			view:             View{Status: 409, Code: "Busy", Message: "widget is busy"},
+		},
+	}
+	for i, tt := range tests {
+		if got, want := tt.err.Error(), tt.errText; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		for _, err := range []error{tt.err, errors.Wrap(tt.err, "wrapped")} {
+			if got, want := IsTemporary(err), true; got != want {
+				t.Errorf("%d: want=%v, got=%v", i, want, got)
+			}
+			if got, want := StatusCode(err), tt.status; got != want {
+				t.Errorf("%d: want=%v, got=%v", i, want, got)
+			}
+			if got, want := Code(err), tt.code; got != want {
+				t.Errorf("%d: want=%v, got=%v", i, want, got)
+			}
+			if got, want := MaxRetries(err), tt.maxRetries; got != want {
+				t.Errorf("%d: want=%v, got=%v", i, want, got)
+			}
+			if got, want := PublicView(err), tt.view; got != want {
+				t.Errorf("%d: want=%+v, got=%+v", i, want, got)
+			}
+		}
+		err := errors.Cause(tt.err)
+		if got, want := HasPublicMessage(err), tt.publicMessage; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := HasPublicStatusCode(err), tt.publicStatusCode; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := HasPublicCode(err), tt.publicCode; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
+
+func TestMaxRetries(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: nil, want: 0},
+		{err: Temporary("timeout"), want: 0},
+		{err: WithOptions(Temporary("timeout"), SetMaxRetries(2)), want: 2},
+		{err: WithOptions(errors.New("timeout"), SetMaxRetries(4)), want: 4},
+		{
+			err:  WithOptions(errors.Wrap(TemporaryWithStatus("busy", 503, SetMaxRetries(3)), "wrapped"), SetMaxRetries(1)),
+			want: 1,
+		},
+	}
+	for i, tt := range tests {
+		if got, want := MaxRetries(tt.err), tt.want; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}
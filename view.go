@@ -0,0 +1,83 @@
+package errkind
+
+import (
+	"net/http"
+	"strings"
+)
+
+// View contains the details of an error that can be returned
+// to a requesting client.
+type View struct {
+	Status   int    `json:"status"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message"`
+	Incident string `json:"incident,omitempty"`
+}
+
+// DefaultView is the fallback view used by PublicView for
+// errors that do not have a public status code or message.
+var DefaultView = View{
+	Status:  http.StatusInternalServerError,
+	Message: "internal server error",
+}
+
+// messager is an interface implemented by errors that have a message
+// that is different to their Error() string.
+type messager interface {
+	Message() string
+}
+
+// PublicView returns a view of err that is safe to return to a requesting
+// client. It is the same as calling PublicViewWithFallback with DefaultView.
+func PublicView(err error) View {
+	return PublicViewWithFallback(err, DefaultView)
+}
+
+// PublicViewWithFallback returns a view of err that is safe to return to a
+// requesting client. The view is based on the cause of err (or the public
+// error supplied to WithPublic if err was created by WithPublic), and each field
+// of the view is only populated from the error if the error indicates that it
+// is public:
+//  Status   is populated if HasPublicStatusCode is true
+//  Code     is populated if HasPublicCode is true
+//  Message  is populated if HasPublicMessage is true
+//  Incident is always populated from IncidentID
+//
+// If the status code is not public, the fallback status is used. If the code
+// is not public, the fallback code is used. If the message is not public but
+// the status code is, the message is the text for the status code (eg "not found"
+// for status 404). Otherwise the fallback message is used.
+//
+// If err is nil, the zero view is returned.
+func PublicViewWithFallback(err error, fallback View) View {
+	if err == nil {
+		return View{}
+	}
+	view := fallback
+	if id := IncidentID(err); id != "" {
+		view.Incident = id
+	}
+	err = cause(err)
+	var publicStatus bool
+	if HasPublicStatusCode(err) {
+		if status := StatusCode(err); status != 0 {
+			view.Status = status
+			publicStatus = true
+		}
+	}
+	if HasPublicCode(err) {
+		view.Code = Code(err)
+	}
+	if HasPublicMessage(err) {
+		if m, ok := err.(messager); ok {
+			view.Message = m.Message()
+		} else {
+			view.Message = err.Error()
+		}
+	} else if publicStatus {
+		if text := http.StatusText(view.Status); text != "" {
+			view.Message = strings.ToLower(text)
+		}
+	}
+	return view
+}
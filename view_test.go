@@ -0,0 +1,130 @@
+package errkind
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jjeffery/errors"
+)
+
+func TestPublicView(t *testing.T) {
+	tests := []struct {
+		err      error
+		fallback *View
+		want     View
+	}{
+		{
+			err:  nil,
+			want: View{},
+		},
+		{
+			err:  errors.New("database password is xyzzy"),
+			want: View{Status: 500, Message: "internal server error"},
+		},
+		{
+			err:  testingStatusError(409),
+			want: View{Status: 500, Message: "internal server error"},
+		},
+		{
+			err:  Public("widget not available", 409),
+			want: View{Status: 409, Message: "widget not available"},
+		},
+		{
+			err:  errors.Wrap(Public("widget not available", 409), "cannot get widget").With("id", 1),
+			want: View{Status: 409, Message: "widget not available"},
+		},
+		{
+			err:  Public("widget not available", 409).With("id", 1),
+			want: View{Status: 409, Message: "widget not available"},
+		},
+		{
+			err:  fmt.Errorf("get widget: %w", PublicWithCode("widget locked", 409, "Locked")),
+			want: View{Status: 409, Code: "Locked", Message: "widget locked"},
+		},
+		{
+			err:  fmt.Errorf("get widget: %w", WithPublic(errors.New("db down"), NotFound())),
+			want: View{Status: 404, Message: "not found"},
+		},
+		{
+			err:  Join(Public("first", 400), Public("second", 409)),
+			want: View{Status: 500, Message: "internal server error"},
+		},
+		{
+			err:  Public("no status", 0),
+			want: View{Status: 500, Message: "no status"},
+		},
+		{
+			err:  PublicWithCode("widget not available", 409, "NoWidget").With("id", 1),
+			want: View{Status: 409, Code: "NoWidget", Message: "widget not available"},
+		},
+		{
+			err:  NotFound("table widgets does not exist"),
+			want: View{Status: 404, Message: "not found"},
+		},
+		{
+			err:  errors.Wrap(BadRequest("invalid id")),
+			want: View{Status: 400, Message: "bad request"},
+		},
+		{
+			err:      errors.New("database password is xyzzy"),
+			fallback: &View{Status: 503, Code: "Unavailable", Message: "unavailable"},
+			want:     View{Status: 503, Code: "Unavailable", Message: "unavailable"},
+		},
+		{
+			err:      Forbidden(),
+			fallback: &View{Status: 503, Code: "Unavailable", Message: "unavailable"},
+			want:     View{Status: 403, Code: "Unavailable", Message: "forbidden"},
+		},
+	}
+	for i, tt := range tests {
+		var got View
+		if tt.fallback == nil {
+			got = PublicView(tt.err)
+		} else {
+			got = PublicViewWithFallback(tt.err, *tt.fallback)
+		}
+		if want := tt.want; got != want {
+			t.Errorf("%d: want=%+v, got=%+v", i, want, got)
+		}
+	}
+}
+
+func TestHasPublic(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus bool
+		wantCode   bool
+	}{
+		{
+			err: nil,
+		},
+		{
+			err: errors.New("not public"),
+		},
+		{
+			err:        NotFound(),
+			wantStatus: true,
+		},
+		{
+			err:        Public("public", 400),
+			wantStatus: true,
+		},
+		{
+			err:        PublicWithCode("public", 400, "CODE"),
+			wantStatus: true,
+			wantCode:   true,
+		},
+		{
+			// wrapped errors are not public
+			err: PublicWithCode("public", 400, "CODE").With("a", "b"),
+		},
+	}
+	for i, tt := range tests {
+		if got, want := HasPublicStatusCode(tt.err), tt.wantStatus; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+		if got, want := HasPublicCode(tt.err), tt.wantCode; got != want {
+			t.Errorf("%d: want=%v, got=%v", i, want, got)
+		}
+	}
+}